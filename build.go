@@ -0,0 +1,549 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/theobori/lueur/config"
+	"github.com/theobori/lueur/internal/common"
+	"github.com/theobori/lueur/internal/diff"
+	"github.com/theobori/lueur/internal/manifest"
+	"github.com/theobori/lueur/walker"
+)
+
+type BuildActionKind int
+
+const (
+	BuildActionCreate BuildActionKind = iota
+	BuildActionUpdate
+	BuildActionDelete
+)
+
+func (k BuildActionKind) String() string {
+	switch k {
+	case BuildActionCreate:
+		return "create"
+	case BuildActionUpdate:
+		return "update"
+	case BuildActionDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// A change to apply to the output directory
+type buildAction struct {
+	Kind BuildActionKind
+	// Path relative to the output directory
+	Path string
+	// Temporary file holding the content written by the create and update
+	// actions
+	TemporaryPath string
+}
+
+// Every change needed to bring the output directory up to date
+type buildPlan struct {
+	outputDirectoryPath string
+	// The outputs are written there as soon as they are converted
+	temporaryDirectoryPath string
+	actions                []buildAction
+	// Source path of every output, two sources can not have the same output
+	outputs map[string]string
+	// Manifest written once the actions have been applied
+	manifest *manifest.Manifest
+	// Amount of sources already up to date
+	upToDate int
+}
+
+// Data given to the header and footer templates
+type templateData struct {
+	// Source path relative to the input directory
+	Path string
+	// Source file name without its extension
+	Name string
+}
+
+type templateFile struct {
+	t *template.Template
+	// Raw template, part of the fingerprint
+	content []byte
+}
+
+func parseTemplateFile(filePath string) (*templateFile, error) {
+	if filePath == "" {
+		return &templateFile{}, nil
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := template.New(filepath.Base(filePath)).Parse(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateFile{t: t, content: content}, nil
+}
+
+func (t *templateFile) execute(writer io.Writer, data *templateData) error {
+	if t.t == nil {
+		return nil
+	}
+
+	return t.t.Execute(writer, data)
+}
+
+// Everything changing the outputs of a source except its content, the
+// version of lueur included
+func buildFingerprint(
+	options *config.Options,
+	inputFormat walker.InputFormat,
+	header *templateFile,
+	footer *templateFile,
+) (string, error) {
+	optionsData, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+
+	return manifest.Hash(
+		[]byte(Version),
+		optionsData,
+		[]byte(inputFormat.String()),
+		header.content,
+		footer.content,
+	), nil
+}
+
+func isFileExisting(filePath string) bool {
+	_, err := os.Stat(filePath)
+
+	return err == nil
+}
+
+// SHA-256 hexadecimal digest of a file content
+func fileHash(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+
+	_, err = io.Copy(h, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func newBuildPlan(cfg *config.Config) (*buildPlan, error) {
+	directoryPath := cfg.Input
+	outputDirectoryPath := cfg.Output
+
+	err := cfg.LoadIgnoreFile(directoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := parseTemplateFile(cfg.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	footer, err := parseTemplateFile(cfg.Footer)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := manifest.Load(filepath.Join(outputDirectoryPath, manifest.FileName))
+	if err != nil {
+		return nil, err
+	}
+
+	outputDirectoryAbsPath, err := filepath.Abs(outputDirectoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	temporaryDirectoryPath, err := os.MkdirTemp("", "lueur-*")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := buildPlan{
+		outputDirectoryPath:    outputDirectoryPath,
+		temporaryDirectoryPath: temporaryDirectoryPath,
+		outputs:                map[string]string{},
+		manifest:               manifest.New(),
+	}
+
+	err = filepath.WalkDir(directoryPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(directoryPath, path)
+		if err != nil {
+			return err
+		}
+
+		// Skip directories
+		if d.IsDir() {
+			if relativePath != "." && cfg.IsSkippedDirectory(relativePath) {
+				return filepath.SkipDir
+			}
+
+			// The output directory may be inside the input directory
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+
+			if absPath == outputDirectoryAbsPath {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		// Skip the file extensions without input format
+		inputFormat, isConvertible := cfg.InputFormat(path)
+		if !isConvertible {
+			return nil
+		}
+		// Skip the files filtered by the configuration
+		if !cfg.IsIncluded(relativePath) {
+			return nil
+		}
+
+		return plan.addSource(cfg, path, relativePath, inputFormat, header, footer, previous)
+	})
+	if err != nil {
+		plan.close()
+		return nil, err
+	}
+
+	for _, orphan := range previous.Orphans(plan.manifest) {
+		if !isFileExisting(filepath.Join(outputDirectoryPath, orphan)) {
+			continue
+		}
+
+		plan.actions = append(plan.actions, buildAction{
+			Kind: BuildActionDelete,
+			Path: orphan,
+		})
+	}
+
+	return &plan, nil
+}
+
+func (p *buildPlan) addSource(
+	cfg *config.Config,
+	path string,
+	relativePath string,
+	inputFormat walker.InputFormat,
+	header *templateFile,
+	footer *templateFile,
+	previous *manifest.Manifest,
+) error {
+	relativeDirectory := filepath.Dir(relativePath)
+
+	options, err := cfg.WalkerOptions(relativeDirectory)
+	if err != nil {
+		return fmt.Errorf("error: %s with the file: %s", err, path)
+	}
+
+	fingerprint, err := buildFingerprint(
+		cfg.ResolvedOptions(relativeDirectory),
+		inputFormat,
+		header,
+		footer,
+	)
+	if err != nil {
+		return err
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	entry := manifest.Entry{
+		Hash:        manifest.Hash(source),
+		Fingerprint: fingerprint,
+	}
+
+	// The outputs depend on the source when it is split into pages, they
+	// are only known from the previous conversion
+	previousEntry := previous.Sources[relativePath]
+	if previous.IsUpToDate(relativePath, entry) &&
+		p.areOutputsExisting(previousEntry.Outputs) {
+		for _, output := range previousEntry.Outputs {
+			err = p.addOutputPath(output, relativePath)
+			if err != nil {
+				return err
+			}
+		}
+
+		entry.Outputs = previousEntry.Outputs
+		p.manifest.Sources[relativePath] = entry
+		p.upToDate += 1
+		return nil
+	}
+
+	pages, err := processPagesFromSource(
+		source,
+		inputFormat,
+		options,
+		filepath.ToSlash(filepath.Join(relativeDirectory, name)),
+	)
+	if err != nil {
+		return fmt.Errorf("error: %s with the file: %s", err, path)
+	}
+
+	entry.Outputs = []string{}
+	isChanged := false
+
+	for _, page := range pages {
+		output := filepath.FromSlash(page.Path)
+
+		err = p.addOutputPath(output, relativePath)
+		if err != nil {
+			return err
+		}
+
+		entry.Outputs = append(entry.Outputs, output)
+
+		isOutputChanged, err := p.addOutput(output, &page, &templateData{Path: relativePath, Name: name}, header, footer)
+		if err != nil {
+			return err
+		}
+
+		if isOutputChanged {
+			isChanged = true
+		}
+	}
+
+	p.manifest.Sources[relativePath] = entry
+
+	if !isChanged {
+		p.upToDate += 1
+	}
+
+	return nil
+}
+
+// Register the output of a source, an output written twice would
+// overwrite the previous one
+func (p *buildPlan) addOutputPath(output string, relativePath string) error {
+	source, isTaken := p.outputs[output]
+	if isTaken {
+		return fmt.Errorf("error: the output %s of the file %s is already written from the file %s", output, relativePath, source)
+	}
+
+	p.outputs[output] = relativePath
+
+	return nil
+}
+
+func (p *buildPlan) areOutputsExisting(outputs []string) bool {
+	for _, output := range outputs {
+		if !isFileExisting(filepath.Join(p.outputDirectoryPath, output)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add the action writing an output, its content is written into a
+// temporary file. False is returned if the output already has this content.
+func (p *buildPlan) addOutput(
+	output string,
+	page *walker.Page,
+	data *templateData,
+	header *templateFile,
+	footer *templateFile,
+) (bool, error) {
+	file, err := os.CreateTemp(p.temporaryDirectoryPath, "output-*")
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	writer := io.MultiWriter(file, h)
+
+	// The code files are written without header and footer
+	if !page.IsCodeFile {
+		err = header.execute(writer, data)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	_, err = io.WriteString(writer, page.Content)
+	if err != nil {
+		return false, err
+	}
+
+	if !page.IsCodeFile {
+		err = footer.execute(writer, data)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	outputPath := filepath.Join(p.outputDirectoryPath, output)
+
+	kind := BuildActionCreate
+	if isFileExisting(outputPath) {
+		kind = BuildActionUpdate
+
+		// The output may have been written without manifest
+		current, err := fileHash(outputPath)
+		if err == nil && current == hex.EncodeToString(h.Sum(nil)) {
+			return false, os.Remove(file.Name())
+		}
+	}
+
+	p.actions = append(p.actions, buildAction{
+		Kind:          kind,
+		Path:          output,
+		TemporaryPath: file.Name(),
+	})
+
+	return true, nil
+}
+
+// Remove the temporary files of the outputs
+func (p *buildPlan) close() error {
+	return os.RemoveAll(p.temporaryDirectoryPath)
+}
+
+// Write an output atomically from its temporary file
+func writeOutput(outputPath string, temporaryPath string) error {
+	file, err := os.Open(temporaryPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return common.WriteFileAtomicFrom(outputPath, file, 0o644)
+}
+
+// Remove the empty directories between a deleted file and the output directory
+func removeEmptyDirectories(outputDirectoryPath string, relativePath string) error {
+	for dir := filepath.Dir(relativePath); dir != "."; dir = filepath.Dir(dir) {
+		err := os.Remove(filepath.Join(outputDirectoryPath, dir))
+		if err != nil {
+			// The directory is not empty
+			if errors.Is(err, fs.ErrExist) || isFileExisting(filepath.Join(outputDirectoryPath, dir)) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Write the actions without applying them, with the unified diff of
+// every output when showDiff is true
+func (p *buildPlan) preview(writer io.Writer, showDiff bool) error {
+	for _, action := range p.actions {
+		outputPath := filepath.Join(p.outputDirectoryPath, action.Path)
+
+		_, err := fmt.Fprintf(writer, "%s %s\n", action.Kind.String(), outputPath)
+		if err != nil {
+			return err
+		}
+
+		if !showDiff {
+			continue
+		}
+
+		current := []byte{}
+		if action.Kind != BuildActionCreate {
+			current, err = os.ReadFile(outputPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		content := []byte{}
+		if action.Kind != BuildActionDelete {
+			content, err = os.ReadFile(action.TemporaryPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		nameA, nameB := outputPath, outputPath
+		switch action.Kind {
+		case BuildActionCreate:
+			nameA = os.DevNull
+		case BuildActionDelete:
+			nameB = os.DevNull
+		}
+
+		_, err = io.WriteString(
+			writer,
+			diff.Unified(nameA, nameB, string(current), string(content)),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Every file is written atomically, the manifest is written last so an
+// interrupted build is completed by the next one
+func (p *buildPlan) apply() error {
+	for _, action := range p.actions {
+		outputPath := filepath.Join(p.outputDirectoryPath, action.Path)
+
+		switch action.Kind {
+		case BuildActionCreate, BuildActionUpdate:
+			err := writeOutput(outputPath, action.TemporaryPath)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("The file %s has been written\n", outputPath)
+		case BuildActionDelete:
+			err := os.Remove(outputPath)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			err = removeEmptyDirectories(p.outputDirectoryPath, action.Path)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("The file %s has been removed\n", outputPath)
+		}
+	}
+
+	return p.manifest.Save(filepath.Join(p.outputDirectoryPath, manifest.FileName))
+}
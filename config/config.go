@@ -0,0 +1,314 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/theobori/lueur/walker"
+)
+
+const (
+	DefaultFileName = "lueur.toml"
+	// Exclude patterns read from the input directory
+	IgnoreFileName = ".lueurignore"
+)
+
+// Input formats of the files converted by default
+var DefaultExtensions = map[string]string{
+	".md":       walker.InputFormatMarkdown.String(),
+	".markdown": walker.InputFormatMarkdown.String(),
+	".html":     walker.InputFormatHTML.String(),
+	".htm":      walker.InputFormatHTML.String(),
+}
+
+// Override applies options to every file inside a directory
+type Override struct {
+	// Directory relative to the input directory
+	Directory string `toml:"directory"`
+	Options
+}
+
+type Config struct {
+	Options
+	// Input directory
+	Input string `toml:"input"`
+	// Output directory
+	Output string `toml:"output"`
+	// Glob patterns of the files to convert, every file by default
+	Include []string `toml:"include"`
+	// Glob patterns of the files and directories to skip
+	Exclude []string `toml:"exclude"`
+	// Input format per file extension, added to the default ones
+	Extensions map[string]string `toml:"extensions"`
+	// Walk the hidden directories, they are skipped by default
+	Hidden bool `toml:"hidden"`
+	// Template written before every converted file
+	Header string `toml:"header"`
+	// Template written after every converted file
+	Footer    string     `toml:"footer"`
+	Overrides []Override `toml:"override"`
+	// Options from the command line, they take precedence over everything
+	Flags Options `toml:"-"`
+}
+
+func NewDefaultConfig() *Config {
+	return &Config{}
+}
+
+func Load(path string) (*Config, error) {
+	c := NewDefaultConfig()
+
+	metadata, err := toml.DecodeFile(path, c)
+	if err != nil {
+		return nil, err
+	}
+
+	undecoded := metadata.Undecoded()
+	if len(undecoded) > 0 {
+		return nil, fmt.Errorf(
+			"unknown configuration key: %s in the file: %s",
+			undecoded[0].String(),
+			path,
+		)
+	}
+
+	// The paths are relative to the directory of the configuration file
+	directory := filepath.Dir(path)
+	for _, p := range []*string{&c.Input, &c.Output, &c.Header, &c.Footer} {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(directory, *p)
+		}
+	}
+
+	err = validatePatterns(slices.Concat(c.Include, c.Exclude))
+	if err != nil {
+		return nil, err
+	}
+
+	// The extensions are normalized
+	extensions := c.Extensions
+	c.Extensions = nil
+
+	for extension, inputFormat := range extensions {
+		err = c.SetExtension(extension + "=" + inputFormat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range c.Overrides {
+		c.Overrides[i].Directory = filepath.Clean(c.Overrides[i].Directory)
+	}
+
+	// The most specific override is applied last
+	slices.SortStableFunc(c.Overrides, func(a, b Override) int {
+		return cmp.Compare(len(a.Directory), len(b.Directory))
+	})
+
+	return c, nil
+}
+
+// ResolvedOptions merges the default options, the file options, the
+// overrides matching the directory and the command line options
+func (c *Config) ResolvedOptions(directory string) *Options {
+	o := NewDefaultOptions()
+	o.Merge(&c.Options)
+
+	for _, override := range c.Overrides {
+		if isSubPath(override.Directory, directory) {
+			o.Merge(&override.Options)
+		}
+	}
+
+	o.Merge(&c.Flags)
+
+	return o
+}
+
+// WalkerOptions returns the walker options for the files inside
+// the directory, relative to the input directory
+func (c *Config) WalkerOptions(directory string) (*walker.Options, error) {
+	return c.ResolvedOptions(directory).WalkerOptions()
+}
+
+// LoadIgnoreFile adds the patterns of the ignore file inside
+// the directory to the exclude patterns, if the file exists
+func (c *Config) LoadIgnoreFile(directory string) error {
+	content, err := os.ReadFile(filepath.Join(directory, IgnoreFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	patterns := []string{}
+	for line := range strings.Lines(string(content)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		patterns = append(patterns, line)
+	}
+
+	return c.AddExclude(patterns...)
+}
+
+func (c *Config) AddInclude(patterns ...string) error {
+	err := validatePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	c.Include = append(c.Include, patterns...)
+
+	return nil
+}
+
+func (c *Config) AddExclude(patterns ...string) error {
+	err := validatePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	c.Exclude = append(c.Exclude, patterns...)
+
+	return nil
+}
+
+// SetExtension maps a file extension to an input format with the
+// syntax "extension=format", the format is Markdown if omitted
+func (c *Config) SetExtension(s string) error {
+	extension, inputFormatString, hasInputFormat := strings.Cut(s, "=")
+	if !hasInputFormat {
+		inputFormatString = walker.InputFormatMarkdown.String()
+	}
+
+	inputFormat, err := walker.NewInputFormatFromString(inputFormatString)
+	if err != nil {
+		return err
+	}
+
+	if extension == "" {
+		return fmt.Errorf("the extension cannot be empty")
+	}
+
+	if !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	extensions := maps.Clone(c.Extensions)
+	if extensions == nil {
+		extensions = map[string]string{}
+	}
+
+	extensions[extension] = inputFormat.String()
+	c.Extensions = extensions
+
+	return nil
+}
+
+// InputFormat returns the input format of a file from its extension,
+// false is returned if the file must not be converted
+func (c *Config) InputFormat(path string) (walker.InputFormat, bool) {
+	extension := filepath.Ext(path)
+
+	inputFormatString, hasExtension := c.Extensions[extension]
+	if !hasExtension {
+		inputFormatString, hasExtension = DefaultExtensions[extension]
+	}
+
+	if !hasExtension {
+		return walker.InputFormatMarkdown, false
+	}
+
+	// The values have been validated when they have been set
+	inputFormat, _ := walker.NewInputFormatFromString(inputFormatString)
+
+	return inputFormat, true
+}
+
+// IsSkippedDirectory reports whether a directory, relative to the input
+// directory, is hidden or excluded
+func (c *Config) IsSkippedDirectory(path string) bool {
+	if !c.Hidden && strings.HasPrefix(filepath.Base(path), ".") {
+		return true
+	}
+
+	return c.IsExcluded(path)
+}
+
+// IsExcluded reports whether a file or a directory, relative to the
+// input directory, matches one of the exclude patterns
+func (c *Config) IsExcluded(path string) bool {
+	return matchAny(c.Exclude, path)
+}
+
+// IsIncluded reports whether a file, relative to the input directory,
+// matches the include patterns and none of the exclude patterns
+func (c *Config) IsIncluded(path string) bool {
+	if matchAny(c.Exclude, path) {
+		return false
+	}
+
+	return len(c.Include) == 0 || matchAny(c.Include, path)
+}
+
+func isSubPath(directory string, path string) bool {
+	path = filepath.Clean(path)
+
+	return directory == "." ||
+		directory == path ||
+		strings.HasPrefix(path, directory+string(filepath.Separator))
+}
+
+// A pattern without separator is matched against every path element,
+// so excluding a directory excludes everything inside it
+func matchAny(patterns []string, path string) bool {
+	path = filepath.Clean(path)
+	elements := strings.Split(path, string(filepath.Separator))
+
+	for _, pattern := range patterns {
+		pattern = filepath.Clean(pattern)
+
+		if strings.ContainsRune(pattern, filepath.Separator) {
+			for i := range elements {
+				matched, _ := filepath.Match(pattern, filepath.Join(elements[:i+1]...))
+				if matched {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		for _, element := range elements {
+			matched, _ := filepath.Match(pattern, element)
+			if matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		_, err := filepath.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("%s with the pattern: %s", err, pattern)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/theobori/lueur/walker"
+)
+
+func testLoadHelper(t *testing.T, content string) *Config {
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestResolvedOptions(t *testing.T) {
+	c := testLoadHelper(t, `
+domain = "example.com"
+path-prefix = "/root"
+file-format = "gph"
+input = "posts"
+exclude = ["drafts"]
+
+[[override]]
+directory = "docs/api"
+word-wrap-limit = 60
+
+[[override]]
+directory = "docs"
+path-prefix = "/docs"
+word-wrap-limit = 50
+`)
+
+	wordWrapLimit := 70
+	c.Flags = Options{WordWrapLimit: &wordWrapLimit}
+
+	tests := []struct {
+		directory     string
+		pathPrefix    string
+		wordWrapLimit int
+	}{
+		{directory: ".", pathPrefix: "/root", wordWrapLimit: 70},
+		{directory: "docs", pathPrefix: "/docs", wordWrapLimit: 70},
+		{directory: "docs/api/v1", pathPrefix: "/docs", wordWrapLimit: 70},
+		{directory: "documents", pathPrefix: "/root", wordWrapLimit: 70},
+	}
+
+	for _, test := range tests {
+		o, err := c.WalkerOptions(test.directory)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if o.PathPrefix != test.pathPrefix || o.WordWrapLimit() != test.wordWrapLimit {
+			t.Fatalf(
+				"'%s' and %d are not the right options for the directory: '%s' (expected: '%s' and %d)",
+				o.PathPrefix,
+				o.WordWrapLimit(),
+				test.directory,
+				test.pathPrefix,
+				test.wordWrapLimit,
+			)
+		}
+
+		if o.Domain() != "example.com" || o.FileFormat().String() != "gph" {
+			t.Fatalf("the file options have not been applied for the directory: '%s'", test.directory)
+		}
+	}
+
+	// Without the command line options, the most specific override wins
+	c.Flags = Options{}
+
+	o := c.ResolvedOptions("docs/api")
+	if *o.WordWrapLimit != 60 {
+		t.Fatalf("%d is not the right word wrap limit (expected: 60)", *o.WordWrapLimit)
+	}
+}
+
+func TestHeadingStyles(t *testing.T) {
+	c := testLoadHelper(t, `
+domain = "example.com"
+heading-styles = { 1 = "figlet", 2 = "uppercase,underline" }
+
+[[override]]
+directory = "docs"
+heading-styles = { 2 = "box" }
+`)
+
+	tests := []struct {
+		directory string
+		level     int
+		expected  []walker.HeadingStyle
+	}{
+		{directory: ".", level: 1, expected: []walker.HeadingStyle{walker.HeadingStyleFIGlet}},
+		{directory: ".", level: 2, expected: []walker.HeadingStyle{walker.HeadingStyleUppercase, walker.HeadingStyleUnderline}},
+		{directory: ".", level: 3, expected: nil},
+		{directory: "docs", level: 1, expected: []walker.HeadingStyle{walker.HeadingStyleFIGlet}},
+		{directory: "docs", level: 2, expected: []walker.HeadingStyle{walker.HeadingStyleBox}},
+	}
+
+	for _, test := range tests {
+		o, err := c.WalkerOptions(test.directory)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(o.HeadingStyles(test.level), test.expected) {
+			t.Fatalf(
+				"%v are not the right heading styles for the level %d in the directory: '%s' (expected: %v)",
+				o.HeadingStyles(test.level),
+				test.level,
+				test.directory,
+				test.expected,
+			)
+		}
+	}
+
+	c = testLoadHelper(t, `heading-styles = { 7 = "box" }`)
+
+	_, err := c.WalkerOptions(".")
+	if err == nil {
+		t.Fatal("the heading level 7 should be invalid")
+	}
+}
+
+func TestQuoteLanguage(t *testing.T) {
+	c := testLoadHelper(t, "domain = \"example.com\"\nquote-language = \"fr-CA\"")
+
+	o, err := c.WalkerOptions(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if o.QuoteLanguage() != "fr-CA" {
+		t.Fatalf("'%s' is not the right quote language (expected: 'fr-CA')", o.QuoteLanguage())
+	}
+
+	c = testLoadHelper(t, "domain = \"example.com\"\nquote-language = \"xx\"")
+
+	_, err = c.WalkerOptions(".")
+	if err == nil {
+		t.Fatal("the quote language xx should be invalid")
+	}
+}
+
+func TestIsIncluded(t *testing.T) {
+	c := &Config{
+		Include: []string{"*.md", "notes/*.markdown"},
+		Exclude: []string{"drafts", "_*", "posts/old"},
+	}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "index.md", expected: true},
+		{path: "a/b/index.md", expected: true},
+		{path: "notes/a.markdown", expected: true},
+		{path: "a.markdown", expected: false},
+		{path: "drafts/a.md", expected: false},
+		{path: "a/drafts/a.md", expected: false},
+		{path: "_private.md", expected: false},
+		{path: "posts/old/a.md", expected: false},
+		{path: "posts/older/a.md", expected: true},
+	}
+
+	for _, test := range tests {
+		if c.IsIncluded(test.path) != test.expected {
+			t.Fatalf("the path '%s' should be included: %t", test.path, test.expected)
+		}
+	}
+}
+
+func TestLoadUnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+
+	err := os.WriteFile(path, []byte("unknown-key = 1\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(path)
+	if err == nil {
+		t.Fatal("an unknown key should not be accepted")
+	}
+}
+
+func TestLoadRelativePaths(t *testing.T) {
+	directory := t.TempDir()
+	path := filepath.Join(directory, DefaultFileName)
+
+	err := os.WriteFile(path, []byte(`
+input = "src"
+output = "/srv/gopher"
+header = "templates/header.gph"
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The configuration file is loaded from another directory
+	t.Chdir(t.TempDir())
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{c.Input, filepath.Join(directory, "src")},
+		{c.Output, "/srv/gopher"},
+		{c.Header, filepath.Join(directory, "templates", "header.gph")},
+		{c.Footer, ""},
+	}
+
+	for _, test := range tests {
+		if test.path != test.expected {
+			t.Fatalf("'%s' is not the right path (expected: '%s')", test.path, test.expected)
+		}
+	}
+
+	// The default configuration file is read from the working directory
+	t.Chdir(directory)
+
+	c, err = Load(DefaultFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Input != "src" {
+		t.Fatalf("'%s' is not the right input directory (expected: 'src')", c.Input)
+	}
+}
+
+func TestInputFormat(t *testing.T) {
+	c := testLoadHelper(t, `
+[extensions]
+html = "html"
+".mdx" = "markdown"
+`)
+
+	err := c.SetExtension(".htm=html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path          string
+		expected      walker.InputFormat
+		isConvertible bool
+	}{
+		{path: "a.md", expected: walker.InputFormatMarkdown, isConvertible: true},
+		{path: "a/b.markdown", expected: walker.InputFormatMarkdown, isConvertible: true},
+		{path: "a.mdx", expected: walker.InputFormatMarkdown, isConvertible: true},
+		{path: "a.html", expected: walker.InputFormatHTML, isConvertible: true},
+		{path: "a.htm", expected: walker.InputFormatHTML, isConvertible: true},
+		{path: "a.txt", isConvertible: false},
+	}
+
+	for _, test := range tests {
+		inputFormat, isConvertible := c.InputFormat(test.path)
+
+		if isConvertible != test.isConvertible ||
+			(isConvertible && inputFormat != test.expected) {
+			t.Fatalf(
+				"'%s' is not the right input format for the path: '%s' (expected: '%s')",
+				inputFormat.String(),
+				test.path,
+				test.expected.String(),
+			)
+		}
+	}
+
+	err = c.SetExtension(".a=unknown")
+	if err == nil {
+		t.Fatal("an unknown input format should not be accepted")
+	}
+}
+
+func TestLoadIgnoreFile(t *testing.T) {
+	directory := t.TempDir()
+
+	err := os.WriteFile(
+		filepath.Join(directory, IgnoreFileName),
+		[]byte("# comment\n\ndrafts\n*.tmp.md\n"),
+		0o644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewDefaultConfig()
+
+	err = c.LoadIgnoreFile(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.IsIncluded("drafts/a.md") || c.IsIncluded("a.tmp.md") || !c.IsIncluded("a.md") {
+		t.Fatalf("the ignore file patterns have not been applied: %v", c.Exclude)
+	}
+
+	// A missing ignore file is not an error
+	err = c.LoadIgnoreFile(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsSkippedDirectory(t *testing.T) {
+	c := NewDefaultConfig()
+
+	if !c.IsSkippedDirectory(".git") || !c.IsSkippedDirectory("a/.cache") || c.IsSkippedDirectory("a") {
+		t.Fatal("only the hidden directories should be skipped")
+	}
+
+	c.Hidden = true
+
+	if c.IsSkippedDirectory(".git") {
+		t.Fatal("the hidden directories should not be skipped")
+	}
+}
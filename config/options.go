@@ -0,0 +1,274 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/theobori/lueur/gophermap"
+	"github.com/theobori/lueur/walker"
+)
+
+// Options mirrors every walker option, a nil field is a value that has
+// not been set and that will not override another one when merging
+type Options struct {
+	WordWrapLimit     *int    `toml:"word-wrap-limit"`
+	ReferencePosition *string `toml:"reference-position"`
+	Domain            *string `toml:"domain"`
+	Port              *int    `toml:"port"`
+	FancyHeader       *bool   `toml:"fancy-header"`
+	FileFormat        *string `toml:"file-format"`
+	PathPrefix        *string `toml:"path-prefix"`
+	DropHTMLLayout    *bool   `toml:"drop-html-layout"`
+	TOC               *bool   `toml:"toc"`
+	TOCMaxDepth       *int    `toml:"toc-max-depth"`
+	NumberedHeadings  *bool   `toml:"numbered-headings"`
+	SplitHeadingLevel *int    `toml:"split-heading-level"`
+	// Comma separated heading styles per level
+	HeadingStyles             map[string]string `toml:"heading-styles"`
+	HeadingBlankLinesBefore   *int              `toml:"heading-blank-lines-before"`
+	HeadingBlankLinesAfter    *int              `toml:"heading-blank-lines-after"`
+	TextAlignment             *string           `toml:"text-alignment"`
+	BlockQuoteStyle           *string           `toml:"blockquote-style"`
+	QuoteLanguage             *string           `toml:"quote-language"`
+	ThematicBreakStyle        *string           `toml:"thematic-break-style"`
+	ThematicBreakOrnament     *string           `toml:"thematic-break-ornament"`
+	CodeBlockCaption          *bool             `toml:"code-block-caption"`
+	CodeBlockFrame            *string           `toml:"code-block-frame"`
+	CodeBlockLineNumbers      *bool             `toml:"code-block-line-numbers"`
+	CodeBlockFileMinLines     *int              `toml:"code-block-file-min-lines"`
+	CodeBlockFilePreview      *int              `toml:"code-block-file-preview"`
+	CodeSpanStyle             *string           `toml:"code-span-style"`
+	EmphasisStyle             *string           `toml:"emphasis-style"`
+	StrongStyle               *string           `toml:"strong-style"`
+	DeduplicateReferences     *bool             `toml:"deduplicate-references"`
+	ReferenceSectionLevel     *int              `toml:"reference-section-level"`
+	RestartReferenceNumbering *bool             `toml:"restart-reference-numbering"`
+	Hyphenation               *bool             `toml:"hyphenation"`
+	HyphenationLanguage       *string           `toml:"hyphenation-language"`
+}
+
+func NewDefaultOptions() *Options {
+	return &Options{
+		WordWrapLimit:             ptr(80),
+		ReferencePosition:         ptr("after-block"),
+		Domain:                    ptr(""),
+		Port:                      ptr(gophermap.DefaultGopherPort),
+		FancyHeader:               ptr(false),
+		FileFormat:                ptr("gophermap"),
+		PathPrefix:                ptr(""),
+		DropHTMLLayout:            ptr(false),
+		TOC:                       ptr(false),
+		TOCMaxDepth:               ptr(0),
+		NumberedHeadings:          ptr(false),
+		SplitHeadingLevel:         ptr(0),
+		HeadingStyles:             map[string]string{},
+		HeadingBlankLinesBefore:   ptr(0),
+		HeadingBlankLinesAfter:    ptr(0),
+		TextAlignment:             ptr("left"),
+		BlockQuoteStyle:           ptr("typographic"),
+		QuoteLanguage:             ptr(walker.DefaultQuoteLanguage),
+		ThematicBreakStyle:        ptr("none"),
+		ThematicBreakOrnament:     ptr(walker.DefaultThematicBreakOrnament),
+		CodeBlockCaption:          ptr(false),
+		CodeBlockFrame:            ptr("none"),
+		CodeBlockLineNumbers:      ptr(false),
+		CodeBlockFileMinLines:     ptr(0),
+		CodeBlockFilePreview:      ptr(0),
+		CodeSpanStyle:             ptr("plain"),
+		EmphasisStyle:             ptr("plain"),
+		StrongStyle:               ptr("plain"),
+		DeduplicateReferences:     ptr(false),
+		ReferenceSectionLevel:     ptr(walker.DefaultReferenceSectionLevel),
+		RestartReferenceNumbering: ptr(false),
+		Hyphenation:               ptr(false),
+		HyphenationLanguage:       ptr(walker.DefaultHyphenationLanguage),
+	}
+}
+
+// Merge overrides the fields with every field set in other
+func (o *Options) Merge(other *Options) {
+	mergeField(&o.WordWrapLimit, other.WordWrapLimit)
+	mergeField(&o.ReferencePosition, other.ReferencePosition)
+	mergeField(&o.Domain, other.Domain)
+	mergeField(&o.Port, other.Port)
+	mergeField(&o.FancyHeader, other.FancyHeader)
+	mergeField(&o.FileFormat, other.FileFormat)
+	mergeField(&o.PathPrefix, other.PathPrefix)
+	mergeField(&o.DropHTMLLayout, other.DropHTMLLayout)
+	mergeField(&o.TOC, other.TOC)
+	mergeField(&o.TOCMaxDepth, other.TOCMaxDepth)
+	mergeField(&o.NumberedHeadings, other.NumberedHeadings)
+	mergeField(&o.SplitHeadingLevel, other.SplitHeadingLevel)
+	mergeField(&o.HeadingBlankLinesBefore, other.HeadingBlankLinesBefore)
+	mergeField(&o.HeadingBlankLinesAfter, other.HeadingBlankLinesAfter)
+	mergeField(&o.TextAlignment, other.TextAlignment)
+	mergeField(&o.BlockQuoteStyle, other.BlockQuoteStyle)
+	mergeField(&o.QuoteLanguage, other.QuoteLanguage)
+	mergeField(&o.ThematicBreakStyle, other.ThematicBreakStyle)
+	mergeField(&o.ThematicBreakOrnament, other.ThematicBreakOrnament)
+	mergeField(&o.CodeBlockCaption, other.CodeBlockCaption)
+	mergeField(&o.CodeBlockFrame, other.CodeBlockFrame)
+	mergeField(&o.CodeBlockLineNumbers, other.CodeBlockLineNumbers)
+	mergeField(&o.CodeBlockFileMinLines, other.CodeBlockFileMinLines)
+	mergeField(&o.CodeBlockFilePreview, other.CodeBlockFilePreview)
+	mergeField(&o.CodeSpanStyle, other.CodeSpanStyle)
+	mergeField(&o.EmphasisStyle, other.EmphasisStyle)
+	mergeField(&o.StrongStyle, other.StrongStyle)
+	mergeField(&o.DeduplicateReferences, other.DeduplicateReferences)
+	mergeField(&o.ReferenceSectionLevel, other.ReferenceSectionLevel)
+	mergeField(&o.RestartReferenceNumbering, other.RestartReferenceNumbering)
+	mergeField(&o.Hyphenation, other.Hyphenation)
+	mergeField(&o.HyphenationLanguage, other.HyphenationLanguage)
+
+	// The heading styles are merged per level
+	if len(other.HeadingStyles) > 0 {
+		headingStyles := maps.Clone(o.HeadingStyles)
+		if headingStyles == nil {
+			headingStyles = map[string]string{}
+		}
+
+		maps.Copy(headingStyles, other.HeadingStyles)
+		o.HeadingStyles = headingStyles
+	}
+}
+
+// WalkerOptions converts the options, every field must have been set
+func (o *Options) WalkerOptions() (*walker.Options, error) {
+	referencePosition, err := walker.NewOutputPositionFromString(*o.ReferencePosition)
+	if err != nil {
+		return nil, err
+	}
+
+	fileFormat, err := gophermap.NewFileFormatFromString(*o.FileFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	textAlignment, err := walker.NewTextAlignmentFromString(*o.TextAlignment)
+	if err != nil {
+		return nil, err
+	}
+
+	blockQuoteStyle, err := walker.NewBlockQuoteStyleFromString(*o.BlockQuoteStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	thematicBreakStyle, err := walker.NewThematicBreakStyleFromString(*o.ThematicBreakStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	codeBlockFrame, err := walker.NewCodeBlockFrameFromString(*o.CodeBlockFrame)
+	if err != nil {
+		return nil, err
+	}
+
+	codeSpanStyle, err := walker.NewInlineStyleFromString(*o.CodeSpanStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	emphasisStyle, err := walker.NewInlineStyleFromString(*o.EmphasisStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	strongStyle, err := walker.NewInlineStyleFromString(*o.StrongStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := walker.NewOptions(
+		*o.WordWrapLimit,
+		referencePosition,
+		*o.Domain,
+		*o.Port,
+		*o.FancyHeader,
+		fileFormat,
+		*o.PathPrefix,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = options.SetTableOfContentsMaxDepth(*o.TOCMaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	err = options.SetSplitHeadingLevel(*o.SplitHeadingLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = options.SetCodeBlockFileMinLines(*o.CodeBlockFileMinLines)
+	if err != nil {
+		return nil, err
+	}
+
+	err = options.SetReferenceSectionLevel(*o.ReferenceSectionLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = options.SetQuoteLanguage(*o.QuoteLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = options.SetHyphenationLanguage(*o.HyphenationLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	for levelString, stylesString := range o.HeadingStyles {
+		level, err := strconv.Atoi(levelString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid heading level: %s", levelString)
+		}
+
+		styles, err := walker.NewHeadingStylesFromString(stylesString)
+		if err != nil {
+			return nil, err
+		}
+
+		err = options.SetHeadingStyles(level, styles...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	options.HeadingBlankLinesBefore = *o.HeadingBlankLinesBefore
+	options.HeadingBlankLinesAfter = *o.HeadingBlankLinesAfter
+	options.DropHTMLLayout = *o.DropHTMLLayout
+	options.TableOfContents = *o.TOC
+	options.NumberedHeadings = *o.NumberedHeadings
+	options.TextAlignment = textAlignment
+	options.BlockQuoteStyle = blockQuoteStyle
+	options.ThematicBreakStyle = thematicBreakStyle
+	options.ThematicBreakOrnament = *o.ThematicBreakOrnament
+	options.CodeBlockCaption = *o.CodeBlockCaption
+	options.CodeBlockFrame = codeBlockFrame
+	options.CodeBlockLineNumbers = *o.CodeBlockLineNumbers
+	options.CodeBlockFilePreviewLines = *o.CodeBlockFilePreview
+	options.CodeSpanStyle = codeSpanStyle
+	options.EmphasisStyle = emphasisStyle
+	options.StrongStyle = strongStyle
+	options.DeduplicateReferences = *o.DeduplicateReferences
+	options.RestartReferenceNumbering = *o.RestartReferenceNumbering
+	options.Hyphenation = *o.Hyphenation
+
+	return options, nil
+}
+
+func mergeField[T any](field **T, value *T) {
+	if value != nil {
+		*field = value
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -1,15 +1,16 @@
 { lib, buildGoModule }:
-buildGoModule {
+buildGoModule rec {
   pname = "lueur";
   version = "0.0.1";
 
   src = ./.;
 
-  vendorHash = "sha256-x6ZaLaKB5e0bIaZeepjO1dJfjP7T8hcdGeh/8x3arQY=";
+  vendorHash = "sha256-ETNulXBBz8R2CDm4BtkRnuhaDRNcnuuy8l8XGS7pX2o=";
 
   ldflags = [
     "-s"
     "-w"
+    "-X main.Version=${version}"
   ];
 
   meta = {
@@ -5,12 +5,10 @@ go 1.25.4
 require github.com/yuin/goldmark v1.7.13
 
 require (
-	github.com/muesli/reflow v0.3.0
+	github.com/BurntSushi/toml v1.6.0
+	github.com/rivo/uniseg v0.4.7
 	github.com/sergi/go-diff v1.4.0
+	github.com/yuin/goldmark-meta v1.1.0
 	golang.org/x/net v0.48.0
-)
-
-require (
-	github.com/mattn/go-runewidth v0.0.12 // indirect
-	github.com/rivo/uniseg v0.2.0 // indirect
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,49 @@
+package gophermap
+
+import "strings"
+
+const TabReplacement = "    "
+
+var (
+	// Line breaks are never allowed inside a line field
+	lineBreakReplacer = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+	// Selectors and domains cannot contain any field separator
+	fieldReplacer = strings.NewReplacer("\r\n", "", "\r", "", "\n", "", "\t", "")
+	// The GPH fields are separated with pipes
+	gphReplacer = strings.NewReplacer("|", "\\|")
+)
+
+// EscapeDescription returns a description that can be safely written
+// as a single line for the given file format
+func EscapeDescription(description string, fileFormat FileFormat) string {
+	description = lineBreakReplacer.Replace(description)
+	description = strings.ReplaceAll(description, "\t", TabReplacement)
+
+	switch fileFormat {
+	case FileFormatGPH:
+		description = gphReplacer.Replace(description)
+		// geomyidae would read it as the beginning of a menu entry
+		if strings.HasPrefix(description, "[") {
+			description = "\\" + description
+		}
+	case FileFormatTxt:
+		// A lone dot line is the end of a Gopher text transfer
+		if description == "." {
+			description = ".."
+		}
+	}
+
+	return description
+}
+
+// EscapeField returns a selector or a domain that can be safely written
+// for the given file format
+func EscapeField(field string, fileFormat FileFormat) string {
+	field = fieldReplacer.Replace(field)
+
+	if fileFormat == FileFormatGPH {
+		field = gphReplacer.Replace(field)
+	}
+
+	return field
+}
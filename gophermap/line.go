@@ -29,21 +29,31 @@ func NewLine(
 func (l *Line) StringGPHFormat() string {
 	return fmt.Sprintf(
 		"[%s|%s|%s|%s|%d]",
-		l.ItemType.String(), l.Description, l.Path, l.Domain, l.Port,
+		l.ItemType.String(),
+		EscapeDescription(l.Description, FileFormatGPH),
+		EscapeField(l.Path, FileFormatGPH),
+		EscapeField(l.Domain, FileFormatGPH),
+		l.Port,
 	)
 }
 
 func (l *Line) StringGophermapFormat() string {
 	return fmt.Sprintf(
 		"%s%s%s%s%s%s%s%d",
-		l.ItemType.String(), l.Description, DefaultSeparator, l.Path,
-		DefaultSeparator, l.Domain, DefaultSeparator, l.Port,
+		l.ItemType.String(),
+		EscapeDescription(l.Description, FileFormatGophermap),
+		DefaultSeparator,
+		EscapeField(l.Path, FileFormatGophermap),
+		DefaultSeparator,
+		EscapeField(l.Domain, FileFormatGophermap),
+		DefaultSeparator,
+		l.Port,
 	)
 }
 
 func (l *Line) StringTextFormat() string {
 	// The description should contains everything
-	return l.Description
+	return EscapeDescription(l.Description, FileFormatTxt)
 }
 
 func (l *Line) String() string {
@@ -0,0 +1,75 @@
+package gophermap
+
+import "testing"
+
+func TestLineStringFromFileFormat(t *testing.T) {
+	tests := []struct {
+		line       Line
+		fileFormat FileFormat
+		expected   string
+	}{
+		{
+			line:       Line{ItemTypeInlineText, "a\tb", "/", "localhost", 70},
+			fileFormat: FileFormatGophermap,
+			expected:   "ia    b\t/\tlocalhost\t70",
+		},
+		{
+			line:       Line{ItemTypeHTML, "a\r\nb\nc\rd", "URL:https://a.com\t", "a.com\n", 443},
+			fileFormat: FileFormatGophermap,
+			expected:   "ha b c d\tURL:https://a.com\ta.com\t443",
+		},
+		{
+			line:       Line{ItemTypeInlineText, "[a|b]", "/", "localhost", 70},
+			fileFormat: FileFormatGophermap,
+			expected:   "i[a|b]\t/\tlocalhost\t70",
+		},
+		{
+			line:       Line{ItemTypeInlineText, ".", "/", "localhost", 70},
+			fileFormat: FileFormatGophermap,
+			expected:   "i.\t/\tlocalhost\t70",
+		},
+		{
+			line:       Line{ItemTypeInlineText, "a|b|c", "/", "localhost", 70},
+			fileFormat: FileFormatGPH,
+			expected:   "[i|a\\|b\\|c|/|localhost|70]",
+		},
+		{
+			line:       Line{ItemTypeInlineText, "[a] b", "/", "localhost", 70},
+			fileFormat: FileFormatGPH,
+			expected:   "[i|\\[a] b|/|localhost|70]",
+		},
+		{
+			line:       Line{ItemTypeTextFile, "a\tb\nc", "/a|b.txt\r", "local|host", 70},
+			fileFormat: FileFormatGPH,
+			expected:   "[0|a    b c|/a\\|b.txt|local\\|host|70]",
+		},
+		{
+			line:       Line{ItemTypeInlineText, ".", "/", "localhost", 70},
+			fileFormat: FileFormatTxt,
+			expected:   "..",
+		},
+		{
+			line:       Line{ItemTypeInlineText, "a.\tb\nc", "/", "localhost", 70},
+			fileFormat: FileFormatTxt,
+			expected:   "a.    b c",
+		},
+		{
+			line:       Line{ItemTypeInlineText, "[a|b]", "/", "", 70},
+			fileFormat: FileFormatTxt,
+			expected:   "[a|b]",
+		},
+	}
+
+	for _, test := range tests {
+		s := test.line.StringFromFileFormat(test.fileFormat)
+
+		if s != test.expected {
+			t.Fatalf(
+				"'%s' is not the right %s line (expected: '%s')",
+				s,
+				test.fileFormat.String(),
+				test.expected,
+			)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package common
+
+import "strings"
+
+// PrimaryLanguageSubtag returns the primary subtag of a language tag such
+// as "en-US" or "fr_FR", in lower case
+func PrimaryLanguageSubtag(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	subtag, _, _ := strings.Cut(tag, "-")
+
+	return subtag
+}
@@ -0,0 +1,136 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Unchanged lines written around the changes
+const ContextLines = 3
+
+type lineOperation struct {
+	operation diffmatchpatch.Operation
+	line      string
+}
+
+// Line level differences, the lines keep their line feed
+func lineOperations(a string, b string) []lineOperation {
+	dmp := diffmatchpatch.New()
+
+	runesA, runesB, lines := dmp.DiffLinesToRunes(a, b)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMainRunes(runesA, runesB, false), lines)
+
+	operations := []lineOperation{}
+	for _, d := range diffs {
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line == "" {
+				continue
+			}
+
+			operations = append(operations, lineOperation{d.Type, line})
+		}
+	}
+
+	return operations
+}
+
+// Unified returns the unified diff between a and b, it is empty when
+// they are equal
+func Unified(nameA string, nameB string, a string, b string) string {
+	if a == b {
+		return ""
+	}
+
+	operations := lineOperations(a, b)
+
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", nameA, nameB)
+
+	// Line numbers of the operations in a and b
+	lineA, lineB := 1, 1
+	startsA := make([]int, len(operations))
+	startsB := make([]int, len(operations))
+
+	for i, o := range operations {
+		startsA[i], startsB[i] = lineA, lineB
+
+		switch o.operation {
+		case diffmatchpatch.DiffEqual:
+			lineA += 1
+			lineB += 1
+		case diffmatchpatch.DiffDelete:
+			lineA += 1
+		case diffmatchpatch.DiffInsert:
+			lineB += 1
+		}
+	}
+
+	for i := 0; i < len(operations); {
+		if operations[i].operation == diffmatchpatch.DiffEqual {
+			i += 1
+			continue
+		}
+
+		start := max(i-ContextLines, 0)
+		end := i
+
+		// Merge the changes separated by less than two contexts
+		for j := i; j < len(operations) && j <= end+2*ContextLines; j++ {
+			if operations[j].operation != diffmatchpatch.DiffEqual {
+				end = j
+			}
+		}
+
+		end = min(end+ContextLines, len(operations)-1)
+
+		writeHunk(&builder, operations[start:end+1], startsA[start], startsB[start])
+
+		i = end + 1
+	}
+
+	return builder.String()
+}
+
+func writeHunk(builder *strings.Builder, operations []lineOperation, startA int, startB int) {
+	countA, countB := 0, 0
+
+	for _, o := range operations {
+		if o.operation != diffmatchpatch.DiffInsert {
+			countA += 1
+		}
+
+		if o.operation != diffmatchpatch.DiffDelete {
+			countB += 1
+		}
+	}
+
+	// An empty range starts at the line before it
+	if countA == 0 {
+		startA -= 1
+	}
+
+	if countB == 0 {
+		startB -= 1
+	}
+
+	fmt.Fprintf(builder, "@@ -%d,%d +%d,%d @@\n", startA, countA, startB, countB)
+
+	for _, o := range operations {
+		prefix := " "
+
+		switch o.operation {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		}
+
+		builder.WriteString(prefix + o.line)
+
+		if !strings.HasSuffix(o.line, "\n") {
+			builder.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package diff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{
+			a:        "a\nb\n",
+			b:        "a\nb\n",
+			expected: "",
+		},
+		{
+			a: "a\nb\nc\n",
+			b: "a\nB\nc\n",
+			expected: `--- a
++++ b
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			a: "",
+			b: "a\nb",
+			expected: `--- a
++++ b
+@@ -0,0 +1,2 @@
++a
++b
+\ No newline at end of file
+`,
+		},
+		{
+			a: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b: "0\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			expected: `--- a
++++ b
+@@ -1,4 +1,4 @@
+-1
++0
+ 2
+ 3
+ 4
+@@ -7,4 +7,3 @@
+ 7
+ 8
+ 9
+-10
+`,
+		},
+		{
+			a: "1\n2\n3\n4\n5\n6\n7\n",
+			b: "0\n2\n3\n4\n5\n6\n0\n",
+			expected: `--- a
++++ b
+@@ -1,7 +1,7 @@
+-1
++0
+ 2
+ 3
+ 4
+ 5
+ 6
+-7
++0
+`,
+		},
+	}
+
+	for _, test := range tests {
+		s := Unified("a", "b", test.a, test.b)
+
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right diff (expected: '%s')", s, test.expected)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package figlet
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Signature at the beginning of every FIGlet font file
+const Signature = "flf2a"
+
+// Characters every FIGlet font must define, in this order
+const (
+	firstRequiredCharacter = ' '
+	lastRequiredCharacter  = '~'
+)
+
+// Deutsch characters defined after the required ones
+var deutschCharacters = []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'}
+
+//go:embed fonts/standard.flf
+var standardFontData []byte
+
+var standardFont = sync.OnceValue(func() *Font {
+	font, err := Parse(bytes.NewReader(standardFontData))
+	if err != nil {
+		panic(err)
+	}
+
+	return font
+})
+
+// Font is a FIGlet font, see http://www.jave.de/figlet/figfont.html
+type Font struct {
+	height    int
+	hardblank rune
+	glyphs    map[rune][]string
+}
+
+// Standard returns the bundled standard font
+func Standard() *Font {
+	return standardFont()
+}
+
+func (f *Font) Height() int {
+	return f.height
+}
+
+// The end marks are the last characters of a glyph line
+func trimEndMarks(line string) string {
+	line = strings.TrimRight(line, "\r")
+	if line == "" {
+		return line
+	}
+
+	endMark, _ := utf8.DecodeLastRuneInString(line)
+
+	return strings.TrimRight(line, string(endMark))
+}
+
+func Parse(r io.Reader) (*Font, error) {
+	scanner := bufio.NewScanner(r)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("missing FIGlet font header")
+	}
+
+	header := scanner.Text()
+	if !strings.HasPrefix(header, Signature) || len(header) <= len(Signature) {
+		return nil, fmt.Errorf("invalid FIGlet font signature")
+	}
+
+	hardblank, _ := utf8.DecodeRuneInString(header[len(Signature):])
+	parameters := strings.Fields(header[len(Signature)+utf8.RuneLen(hardblank):])
+	if len(parameters) < 5 {
+		return nil, fmt.Errorf("invalid FIGlet font header: %s", header)
+	}
+
+	height, err := strconv.Atoi(parameters[0])
+	if err != nil || height < 1 {
+		return nil, fmt.Errorf("invalid FIGlet font height: %s", parameters[0])
+	}
+
+	commentLines, err := strconv.Atoi(parameters[4])
+	if err != nil || commentLines < 0 {
+		return nil, fmt.Errorf("invalid FIGlet font comment lines: %s", parameters[4])
+	}
+
+	for range commentLines {
+		scanner.Scan()
+	}
+
+	f := Font{
+		height:    height,
+		hardblank: hardblank,
+		glyphs:    map[rune][]string{},
+	}
+
+	readGlyph := func() ([]string, error) {
+		glyph := make([]string, height)
+
+		for i := range height {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("truncated FIGlet font")
+			}
+
+			glyph[i] = trimEndMarks(scanner.Text())
+		}
+
+		return glyph, nil
+	}
+
+	characters := []rune{}
+	for c := firstRequiredCharacter; c <= lastRequiredCharacter; c++ {
+		characters = append(characters, c)
+	}
+
+	for _, c := range characters {
+		glyph, err := readGlyph()
+		if err != nil {
+			return nil, err
+		}
+
+		f.glyphs[c] = glyph
+	}
+
+	// The optional characters are preceded by their code
+	for _, c := range deutschCharacters {
+		glyph, err := readGlyph()
+		if err != nil {
+			return &f, nil
+		}
+
+		f.glyphs[c] = glyph
+	}
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FIGlet character code: %s", fields[0])
+		}
+
+		glyph, err := readGlyph()
+		if err != nil {
+			return nil, err
+		}
+
+		// The negative codes are not Unicode characters
+		if code >= 0 {
+			f.glyphs[rune(code)] = glyph
+		}
+	}
+
+	return &f, scanner.Err()
+}
+
+// Supports reports whether every character of s has a glyph
+func (f *Font) Supports(s string) bool {
+	for _, c := range s {
+		if _, hasGlyph := f.glyphs[c]; !hasGlyph {
+			return false
+		}
+	}
+
+	return true
+}
+
+func countTrailingSpaces(s []rune) int {
+	count := 0
+	for i := len(s) - 1; i >= 0 && s[i] == ' '; i-- {
+		count += 1
+	}
+
+	return count
+}
+
+func countLeadingSpaces(s []rune) int {
+	count := 0
+	for i := 0; i < len(s) && s[i] == ' '; i++ {
+		count += 1
+	}
+
+	return count
+}
+
+// Render returns the banner lines of s, the glyphs are moved closer
+// until they touch (kerning)
+func (f *Font) Render(s string) ([]string, error) {
+	rows := make([][]rune, f.height)
+
+	for _, c := range s {
+		glyph, hasGlyph := f.glyphs[c]
+		if !hasGlyph {
+			return nil, fmt.Errorf("unsupported FIGlet character: %q", c)
+		}
+
+		glyphRows := make([][]rune, f.height)
+		shift := -1
+
+		for i, line := range glyph {
+			glyphRows[i] = []rune(line)
+
+			space := countTrailingSpaces(rows[i]) + countLeadingSpaces(glyphRows[i])
+			if shift < 0 || space < shift {
+				shift = space
+			}
+		}
+
+		for i := range rows {
+			fromRow := min(shift, countTrailingSpaces(rows[i]))
+			fromGlyph := min(shift-fromRow, len(glyphRows[i]))
+
+			rows[i] = append(rows[i][:len(rows[i])-fromRow], glyphRows[i][fromGlyph:]...)
+		}
+	}
+
+	lines := []string{}
+	for _, row := range rows {
+		line := strings.ReplaceAll(string(row), string(f.hardblank), " ")
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+
+	// Remove the empty lines around the banner
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,116 @@
+package figlet
+
+import (
+	"strings"
+	"testing"
+)
+
+const testFont = `flf2a$ 2 2 4 0 1
+A comment line
+ $@
+ $@@
+#@
+#@@
+` + "\"\"@\n\"\"@@\n"
+
+func testFontWithRequiredCharacters() string {
+	builder := strings.Builder{}
+	builder.WriteString("flf2a$ 2 2 4 0 1\nA comment line\n")
+
+	for c := ' '; c <= '~'; c++ {
+		switch c {
+		case ' ':
+			builder.WriteString("$$@\n$$@@\n")
+		case 'a':
+			builder.WriteString(" a @\naa @@\n")
+		default:
+			builder.WriteString(string(c) + "@\n" + string(c) + "@@\n")
+		}
+	}
+
+	for _, c := range deutschCharacters {
+		builder.WriteString(string(c) + "@\n" + string(c) + "@@\n")
+	}
+
+	builder.WriteString("0x263A  WHITE SMILING FACE\n:)@\n:)@@\n")
+
+	return builder.String()
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse(strings.NewReader(testFont))
+	if err == nil {
+		t.Fatal("a truncated font should not be parsed")
+	}
+
+	_, err = Parse(strings.NewReader("flf2b$ 2 2 4 0 1\n"))
+	if err == nil {
+		t.Fatal("a font without signature should not be parsed")
+	}
+
+	f, err := Parse(strings.NewReader(testFontWithRequiredCharacters()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Height() != 2 {
+		t.Fatalf("%d is not the right height (expected: 2)", f.Height())
+	}
+
+	if !f.Supports("a b ☺") || f.Supports("é") {
+		t.Fatal("wrong supported characters")
+	}
+}
+
+func TestRender(t *testing.T) {
+	f, err := Parse(strings.NewReader(testFontWithRequiredCharacters()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"b", "b\nb"},
+		{"a", " a\naa"},
+		{"aa", " a a\naaaa"},
+		{"b b", "b  b\nb  b"},
+		{"☺", ":)\n:)"},
+	}
+
+	for _, test := range tests {
+		lines, err := f.Render(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := strings.Join(lines, "\n")
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right banner (expected: '%s')", s, test.expected)
+		}
+	}
+
+	_, err = f.Render("é")
+	if err == nil {
+		t.Fatal("an unsupported character should not be rendered")
+	}
+}
+
+func TestStandard(t *testing.T) {
+	lines, err := Standard().Render("Hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ` _   _  _
+| | | |(_)
+| |_| || |
+|  _  || |
+|_| |_||_|`
+
+	s := strings.Join(lines, "\n")
+	if s != expected {
+		t.Fatalf("'%s' is not the right banner (expected: '%s')", s, expected)
+	}
+}
@@ -0,0 +1,248 @@
+package hyphen
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/theobori/lueur/internal/common"
+)
+
+//go:embed patterns
+var patternFiles embed.FS
+
+// A bundled language with its minimum amount of characters before and
+// after a hyphenation point
+type language struct {
+	name     string
+	leftMin  int
+	rightMin int
+}
+
+// The bundled languages per primary language subtag
+var languages = map[string]language{
+	"de": {name: "de-1996", leftMin: 2, rightMin: 2},
+	"en": {name: "en-us", leftMin: 2, rightMin: 3},
+	"es": {name: "es", leftMin: 2, rightMin: 2},
+	"fr": {name: "fr", leftMin: 2, rightMin: 3},
+}
+
+var hyphenators = map[string]func() (*Hyphenator, error){}
+
+func init() {
+	for key, l := range languages {
+		hyphenators[key] = sync.OnceValues(func() (*Hyphenator, error) {
+			return loadLanguage(l)
+		})
+	}
+}
+
+// Hyphenator finds the hyphenation points of the words with the Liang
+// algorithm, see https://tug.org/docs/liang/
+type Hyphenator struct {
+	// Values between the letters of every pattern
+	patterns map[string][]uint8
+	// Hyphenation points of the exception words, as rune indexes
+	exceptions map[string][]int
+	// Length of the longest pattern, in runes
+	maxLength int
+	leftMin   int
+	rightMin  int
+}
+
+// Languages returns the supported language subtags
+func Languages() []string {
+	keys := []string{}
+	for key := range languages {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// IsSupported reports whether there are bundled patterns for the language tag
+func IsSupported(tag string) bool {
+	_, isSupported := languages[common.PrimaryLanguageSubtag(tag)]
+
+	return isSupported
+}
+
+// ForLanguage returns the hyphenator of a language tag, its patterns
+// are parsed once
+func ForLanguage(tag string) (*Hyphenator, error) {
+	hyphenator, isSupported := hyphenators[common.PrimaryLanguageSubtag(tag)]
+	if !isSupported {
+		return nil, fmt.Errorf("unsupported hyphenation language: %s", tag)
+	}
+
+	return hyphenator()
+}
+
+func loadLanguage(l language) (*Hyphenator, error) {
+	patterns, err := patternFiles.Open("patterns/hyph-" + l.name + ".pat.txt")
+	if err != nil {
+		return nil, err
+	}
+	defer patterns.Close()
+
+	// The exceptions are optional
+	var exceptions io.Reader
+	exceptionsFile, err := patternFiles.Open("patterns/hyph-" + l.name + ".hyp.txt")
+	if err == nil {
+		defer exceptionsFile.Close()
+		exceptions = exceptionsFile
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return Parse(patterns, exceptions, l.leftMin, l.rightMin)
+}
+
+// Every whitespace separated field of the lines, the "%" comments are skipped
+func fields(r io.Reader, f func(field string) error) error {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "%")
+
+		for _, field := range strings.Fields(line) {
+			err := f(field)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Parse reads the patterns and the hyphenated exception words, one per
+// line like the hyph-utf8 plain text files. The exceptions may be nil.
+func Parse(patterns io.Reader, exceptions io.Reader, leftMin int, rightMin int) (*Hyphenator, error) {
+	h := Hyphenator{
+		patterns:   map[string][]uint8{},
+		exceptions: map[string][]int{},
+		leftMin:    max(leftMin, 1),
+		rightMin:   max(rightMin, 1),
+	}
+
+	err := fields(patterns, func(pattern string) error {
+		letters := []rune{}
+		values := []uint8{0}
+
+		for _, r := range pattern {
+			if r >= '0' && r <= '9' {
+				values[len(values)-1] = uint8(r - '0')
+				continue
+			}
+
+			letters = append(letters, unicode.ToLower(r))
+			values = append(values, 0)
+		}
+
+		if len(letters) == 0 {
+			return fmt.Errorf("invalid hyphenation pattern: %s", pattern)
+		}
+
+		h.patterns[string(letters)] = values
+		h.maxLength = max(h.maxLength, len(letters))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if exceptions == nil {
+		return &h, nil
+	}
+
+	err = fields(exceptions, func(word string) error {
+		letters := []rune{}
+		points := []int{}
+
+		for _, r := range word {
+			if r == '-' {
+				points = append(points, len(letters))
+				continue
+			}
+
+			letters = append(letters, unicode.ToLower(r))
+		}
+
+		h.exceptions[string(letters)] = points
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+// The rune indexes before which the word can be hyphenated
+func (h *Hyphenator) points(word []rune) []int {
+	lower := make([]rune, len(word))
+	for i, r := range word {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	points, isException := h.exceptions[string(lower)]
+	if isException {
+		return points
+	}
+
+	// The dots are the word boundaries
+	w := append(append([]rune{'.'}, lower...), '.')
+	values := make([]uint8, len(w)+1)
+
+	for i := range w {
+		for j := i + 1; j <= min(len(w), i+h.maxLength); j++ {
+			patternValues, hasPattern := h.patterns[string(w[i:j])]
+			if !hasPattern {
+				continue
+			}
+
+			for k, value := range patternValues {
+				values[i+k] = max(values[i+k], value)
+			}
+		}
+	}
+
+	points = []int{}
+	for i := h.leftMin; i <= len(word)-h.rightMin; i++ {
+		// The value before the rune i of the word follows the first dot
+		if values[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+
+	return points
+}
+
+// Hyphenate splits a word at its hyphenation points
+func (h *Hyphenator) Hyphenate(word string) []string {
+	runes := []rune(word)
+	if len(runes) < h.leftMin+h.rightMin {
+		return []string{word}
+	}
+
+	syllables := []string{}
+	start := 0
+
+	for _, point := range h.points(runes) {
+		syllables = append(syllables, string(runes[start:point]))
+		start = point
+	}
+
+	return append(syllables, string(runes[start:]))
+}
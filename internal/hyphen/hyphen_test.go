@@ -0,0 +1,71 @@
+package hyphen
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHyphenate(t *testing.T) {
+	tests := []struct {
+		language string
+		word     string
+		expected string
+	}{
+		{"en", "hyphenation", "hy-phen-a-tion"},
+		{"en-US", "computer", "com-puter"},
+		{"en", "algorithm", "al-go-rithm"},
+		{"en", "Table", "Ta-ble"},
+		{"en", "project", "project"},
+		{"en", "a", "a"},
+		{"de", "Silbentrennung", "Sil-ben-tren-nung"},
+		{"de_DE", "Donaudampfschifffahrt", "Do-nau-dampf-schiff-fahrt"},
+		{"fr", "ordinateur", "or-di-na-teur"},
+		{"es", "biblioteca", "bi-blio-te-ca"},
+	}
+
+	for _, test := range tests {
+		h, err := ForLanguage(test.language)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := strings.Join(h.Hyphenate(test.word), "-")
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right hyphenation of '%s' (expected: '%s')", s, test.word, test.expected)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	h, err := Parse(
+		strings.NewReader("% comment\n1ba\n.ab4c a1c\n"),
+		strings.NewReader("ab-cab\n"),
+		1,
+		1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		word     string
+		expected []string
+	}{
+		{"abac", []string{"a", "ba", "c"}},
+		{"abc", []string{"abc"}},
+		{"abcab", []string{"ab", "cab"}},
+	}
+
+	for _, test := range tests {
+		syllables := h.Hyphenate(test.word)
+		if !slices.Equal(syllables, test.expected) {
+			t.Fatalf("%v is not the right hyphenation of '%s' (expected: %v)", syllables, test.word, test.expected)
+		}
+	}
+
+	_, err = ForLanguage("ja")
+	if err == nil {
+		t.Fatal("the language ja should be unsupported")
+	}
+}
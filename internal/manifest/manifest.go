@@ -0,0 +1,145 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/theobori/lueur/internal/common"
+)
+
+const (
+	// Name of the manifest file written in the output directory
+	FileName = ".lueur-manifest.json"
+	// Version of the manifest file format
+	Version = 1
+)
+
+// Entry describes the last conversion of a source file
+type Entry struct {
+	// Hash of the source file content
+	Hash string `json:"hash"`
+	// Hash of everything else changing the outputs, such as the options
+	Fingerprint string `json:"fingerprint"`
+	// Output paths relative to the output directory
+	Outputs []string `json:"outputs"`
+}
+
+// Manifest records the conversions of a directory, the sources paths are
+// relative to the input directory
+type Manifest struct {
+	Version int              `json:"version"`
+	Sources map[string]Entry `json:"sources"`
+}
+
+func New() *Manifest {
+	return &Manifest{
+		Version: Version,
+		Sources: map[string]Entry{},
+	}
+}
+
+// Load reads a manifest, an empty one is returned if the file does not exist
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := New()
+
+	err = json.Unmarshal(data, m)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %s: %w", path, err)
+	}
+
+	// Every source is converted again with another manifest version
+	if m.Version != Version {
+		return New(), nil
+	}
+
+	if m.Sources == nil {
+		m.Sources = map[string]Entry{}
+	}
+
+	// The orphan outputs are removed, they must not escape the output
+	// directory
+	for source, entry := range m.Sources {
+		for _, output := range entry.Outputs {
+			if !filepath.IsLocal(output) {
+				return nil, fmt.Errorf("invalid manifest: %s: the output %s of %s is not local", path, output, source)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// Save writes the manifest atomically
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return common.WriteFileAtomic(path, append(data, '\n'), 0o644)
+}
+
+// IsUpToDate reports whether the source has already been converted with
+// the same content and the same fingerprint, the outputs are not compared
+// since they are only known once the source is converted
+func (m *Manifest) IsUpToDate(source string, entry Entry) bool {
+	previous, hasSource := m.Sources[source]
+	if !hasSource {
+		return false
+	}
+
+	return previous.Hash == entry.Hash &&
+		previous.Fingerprint == entry.Fingerprint
+}
+
+// Orphans returns the outputs recorded in the manifest that are not
+// produced by the current entries anymore
+func (m *Manifest) Orphans(current *Manifest) []string {
+	outputs := map[string]bool{}
+	for _, entry := range current.Sources {
+		for _, output := range entry.Outputs {
+			outputs[output] = true
+		}
+	}
+
+	orphans := []string{}
+	for _, entry := range m.Sources {
+		for _, output := range entry.Outputs {
+			if !outputs[output] {
+				orphans = append(orphans, output)
+			}
+		}
+	}
+
+	slices.Sort(orphans)
+
+	return slices.Compact(orphans)
+}
+
+// Hash returns the SHA-256 hexadecimal digest of every data
+func Hash(data ...[]byte) string {
+	h := sha256.New()
+
+	for _, d := range data {
+		// The lengths avoid collisions between different splits
+		fmt.Fprintf(h, "%d:", len(d))
+		h.Write(d)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
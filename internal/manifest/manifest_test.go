@@ -0,0 +1,117 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.Sources) != 0 {
+		t.Fatal("a missing manifest should be empty")
+	}
+
+	m.Sources["a.md"] = Entry{Hash: "a", Fingerprint: "b", Outputs: []string{"a.gph"}}
+
+	err = m.Save(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !loaded.IsUpToDate("a.md", m.Sources["a.md"]) {
+		t.Fatal("the saved entry should be up to date")
+	}
+
+	err = os.WriteFile(path, []byte("{"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(path)
+	if err == nil {
+		t.Fatal("an invalid manifest should not be loaded")
+	}
+}
+
+func TestLoadNotLocalOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	for _, output := range []string{"../../a.gph", "/a.gph", ""} {
+		m := New()
+		m.Sources["a.md"] = Entry{Outputs: []string{output}}
+
+		err := m.Save(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Load(path)
+		if err == nil {
+			t.Fatalf("the output %q should not be loaded", output)
+		}
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	m := New()
+	m.Sources["a.md"] = Entry{Hash: "a", Fingerprint: "b", Outputs: []string{"a.gph"}}
+
+	tests := []struct {
+		source   string
+		entry    Entry
+		expected bool
+	}{
+		{"a.md", Entry{Hash: "a", Fingerprint: "b", Outputs: []string{"a.gph"}}, true},
+		{"a.md", Entry{Hash: "c", Fingerprint: "b", Outputs: []string{"a.gph"}}, false},
+		{"a.md", Entry{Hash: "a", Fingerprint: "c", Outputs: []string{"a.gph"}}, false},
+		{"a.md", Entry{Hash: "a", Fingerprint: "b"}, true},
+		{"b.md", Entry{Hash: "a", Fingerprint: "b", Outputs: []string{"b.gph"}}, false},
+	}
+
+	for _, test := range tests {
+		if m.IsUpToDate(test.source, test.entry) != test.expected {
+			t.Fatalf("wrong up to date state for %s (expected: %t)", test.source, test.expected)
+		}
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	previous := New()
+	previous.Sources["a.md"] = Entry{Outputs: []string{"a.gph"}}
+	previous.Sources["b.md"] = Entry{Outputs: []string{"b.gph"}}
+	previous.Sources["c/d.md"] = Entry{Outputs: []string{"c/d.gph"}}
+
+	current := New()
+	current.Sources["a.md"] = Entry{Outputs: []string{"a.txt"}}
+	current.Sources["b.md"] = Entry{Outputs: []string{"b.gph"}}
+
+	orphans := previous.Orphans(current)
+	expected := []string{"a.gph", "c/d.gph"}
+
+	if !slices.Equal(orphans, expected) {
+		t.Fatalf("%v are not the right orphans (expected: %v)", orphans, expected)
+	}
+}
+
+func TestHash(t *testing.T) {
+	if Hash([]byte("ab"), []byte("c")) == Hash([]byte("a"), []byte("bc")) {
+		t.Fatal("different splits should not have the same hash")
+	}
+
+	if Hash([]byte("a")) != Hash([]byte("a")) {
+		t.Fatal("the hash should be deterministic")
+	}
+}
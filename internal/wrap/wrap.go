@@ -0,0 +1,255 @@
+package wrap
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// ANSI escape sequence changing the style of the text
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Width returns the display width of s in a monospace terminal, the
+// East Asian wide characters and most emojis take two columns, the ANSI
+// style sequences take none
+func Width(s string) int {
+	if isPrintableASCII(s) {
+		return len(s)
+	}
+
+	if strings.Contains(s, "\x1b") {
+		s = ansiSequence.ReplaceAllString(s, "")
+	}
+
+	return uniseg.StringWidth(s)
+}
+
+// Reports whether s is only made of printable ASCII characters, they are
+// one column wide
+func isPrintableASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SoftHyphen marks a hyphenation point, it is written as a hyphen at the
+// end of a wrapped line and removed anywhere else
+const SoftHyphen = "\u00AD"
+
+// A text part ending with a line break opportunity
+type segment struct {
+	text string
+	// Width without the trailing spaces
+	width int
+}
+
+// The ASCII characters whose only line break opportunities are after the
+// spaces, the letters and a few punctuation marks. The digits, the hyphens,
+// the brackets and the other punctuation marks follow the UAX #14 rules.
+var simpleASCII = func() [256]bool {
+	table := [256]bool{}
+
+	for c := range 128 {
+		table[c] = 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+
+	for _, c := range " .,;:'\"" {
+		table[c] = true
+	}
+
+	return table
+}()
+
+// Reports whether s is only made of simple ASCII characters
+func isSimpleASCII(s string) bool {
+	for i := range len(s) {
+		if !simpleASCII[s[i]] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Split a simple ASCII line after its spaces, it is faster than the UAX #14
+// segmenter for the most common lines and gives the same segments. The
+// lines are never broken before the punctuation marks, even after spaces.
+func simpleASCIISegments(s string) []segment {
+	result := []segment{}
+	end := 0
+
+	for end < len(s) {
+		for end < len(s) && s[end] != ' ' {
+			end += 1
+		}
+
+		for end < len(s) && s[end] == ' ' {
+			end += 1
+		}
+
+		if end < len(s) && strings.IndexByte(".,;:", s[end]) >= 0 {
+			continue
+		}
+
+		text := s[:end]
+		result = append(result, segment{
+			text:  text,
+			width: len(strings.TrimRight(text, " ")),
+		})
+		s = s[end:]
+		end = 0
+	}
+
+	return result
+}
+
+// Split a line at its line break opportunities
+func segments(s string) []segment {
+	if isSimpleASCII(s) {
+		return simpleASCIISegments(s)
+	}
+
+	return unicodeSegments(s)
+}
+
+// Split a line at its UAX #14 line break opportunities, a grapheme
+// cluster is never split
+func unicodeSegments(s string) []segment {
+	result := []segment{}
+	builder := strings.Builder{}
+	state := -1
+
+	for len(s) > 0 {
+		var (
+			cluster    string
+			boundaries int
+		)
+
+		cluster, s, boundaries, state = uniseg.StepString(s, state)
+		builder.WriteString(cluster)
+
+		if boundaries&uniseg.MaskLine == uniseg.LineDontBreak && len(s) > 0 {
+			continue
+		}
+
+		text := builder.String()
+		trimmed := strings.TrimRight(text, " ")
+		width := Width(strings.ReplaceAll(trimmed, SoftHyphen, ""))
+
+		// The hyphen written if the line is broken after the segment
+		if strings.HasSuffix(trimmed, SoftHyphen) {
+			width += 1
+		}
+
+		result = append(result, segment{
+			text:  text,
+			width: width,
+		})
+		builder.Reset()
+	}
+
+	return result
+}
+
+// The lines following the first one start with the indent
+func wrapLine(s string, limit int, indent string) []string {
+	lines := []string{}
+	line := strings.Builder{}
+	// Width of the line with its trailing spaces
+	lineWidth := 0
+	hasContent := false
+
+	for _, seg := range segments(s) {
+		if hasContent && seg.width > 0 && lineWidth+seg.width > limit {
+			lines = append(lines, hyphenateLineEnd(strings.TrimRight(line.String(), " ")))
+			line.Reset()
+			line.WriteString(indent)
+			lineWidth = Width(indent)
+			hasContent = false
+
+			seg.text = strings.TrimLeft(seg.text, " ")
+		}
+
+		line.WriteString(seg.text)
+		lineWidth += Width(strings.ReplaceAll(seg.text, SoftHyphen, ""))
+		hasContent = hasContent || seg.width > 0
+	}
+
+	return append(lines, strings.ReplaceAll(line.String(), SoftHyphen, ""))
+}
+
+// The soft hyphen ending a line is written as a hyphen
+func hyphenateLineEnd(line string) string {
+	hasHyphen := strings.HasSuffix(line, SoftHyphen)
+	line = strings.ReplaceAll(line, SoftHyphen, "")
+
+	if hasHyphen {
+		line += "-"
+	}
+
+	return line
+}
+
+// Hanging wraps a single line so it fits within limit columns, the lines
+// following the first one start with the indent. The words wider than the
+// limit are kept whole unless they have soft hyphens.
+func Hanging(s string, limit int, indent string) []string {
+	return wrapLine(s, limit, indent)
+}
+
+// Justify distributes spaces between the words of s, after its prefix,
+// so it is limit columns wide. The first gaps are the widest ones, s is
+// returned as is if it has a single word or does not fit.
+func Justify(s string, limit int, prefix string) string {
+	if !strings.HasPrefix(s, prefix) {
+		prefix = ""
+	}
+
+	words := strings.Fields(s[len(prefix):])
+	gaps := len(words) - 1
+	if gaps < 1 {
+		return s
+	}
+
+	spaces := limit - Width(prefix)
+	for _, word := range words {
+		spaces -= Width(word)
+	}
+
+	if spaces < gaps {
+		return s
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(prefix)
+
+	for i, word := range words {
+		builder.WriteString(word)
+
+		if i < gaps {
+			n := spaces / gaps
+			if i < spaces%gaps {
+				n += 1
+			}
+
+			builder.WriteString(strings.Repeat(" ", n))
+		}
+	}
+
+	return builder.String()
+}
+
+// Center pads s on the left so it is centered within limit columns
+func Center(s string, limit int) string {
+	width := Width(s)
+	if width == 0 || width >= limit {
+		return s
+	}
+
+	return strings.Repeat(" ", (limit-width)/2) + s
+}
@@ -0,0 +1,32 @@
+package wrap
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	benchmarkASCIILine = strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 20)
+	benchmarkCJKLine   = strings.Repeat("日本語のテキストです。", 40)
+)
+
+// The simple ASCII lines skip the UAX #14 segmenter:
+//
+// BenchmarkHangingASCII (segmenter)   241835 ns/op  14448 B/op  296 allocs/op
+// BenchmarkHangingASCII                13648 ns/op  12528 B/op   96 allocs/op
+// BenchmarkHangingCJK                 187083 ns/op  39008 B/op  480 allocs/op
+func BenchmarkHangingASCII(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		Hanging(benchmarkASCIILine, 70, "  ")
+	}
+}
+
+func BenchmarkHangingCJK(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		Hanging(benchmarkCJKLine, 70, "  ")
+	}
+}
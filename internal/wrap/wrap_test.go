@@ -0,0 +1,162 @@
+package wrap
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected int
+	}{
+		{"abc", 3},
+		{"été", 3},
+		{"été", 3},
+		{"日本語", 6},
+		{"👍🏽", 2},
+		{"\x1b[1mbold\x1b[22m", 4},
+		{"", 0},
+	}
+
+	for _, test := range tests {
+		n := Width(test.s)
+
+		if n != test.expected {
+			t.Fatalf("%d is not the right width of '%s' (expected: %d)", n, test.s, test.expected)
+		}
+	}
+}
+
+func TestHangingWithoutIndent(t *testing.T) {
+	tests := []struct {
+		s        string
+		limit    int
+		expected string
+	}{
+		{"aaaaa bbbb", 10, "aaaaa bbbb"},
+		{"aaaaa bbbbb", 10, "aaaaa\nbbbbb"},
+		{"  indented text here", 10, "  indented\ntext here"},
+		{"bb cc", 4, "bb\ncc"},
+		{"averyveryverylongword a", 5, "averyveryverylongword\na"},
+		{"日本語のテキストです", 8, "日本語の\nテキスト\nです"},
+		{"well-known words", 8, "well-\nknown\nwords"},
+		{"😀😀😀 😀😀", 6, "😀😀😀\n😀😀"},
+		{"café café", 4, "café\ncafé"},
+		{"", 10, ""},
+	}
+
+	for _, test := range tests {
+		s := strings.Join(Hanging(test.s, test.limit, ""), "\n")
+
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right wrapping of '%s' (expected: '%s')", s, test.s, test.expected)
+		}
+	}
+}
+
+// The simple ASCII lines are split like the UAX #14 segmenter does
+func TestSimpleASCIISegments(t *testing.T) {
+	alphabet := []string{"a", "B", " ", ".", ",", ";", ":", "'", "\""}
+	lines := []string{""}
+
+	for range 5 {
+		next := []string{}
+
+		for _, line := range lines {
+			for _, c := range alphabet {
+				next = append(next, line+c)
+			}
+		}
+
+		lines = next
+
+		for _, line := range lines {
+			if !isSimpleASCII(line) {
+				t.Fatalf("'%s' should be a simple ASCII line", line)
+			}
+
+			s := simpleASCIISegments(line)
+			expected := unicodeSegments(line)
+
+			if !slices.Equal(s, expected) {
+				t.Fatalf("%q are not the right segments of '%s' (expected: %q)", s, line, expected)
+			}
+		}
+	}
+
+	for _, line := range []string{"well-known", "a(b)", "a!b", "a/b", "a1", "été", "a\tb"} {
+		if isSimpleASCII(line) {
+			t.Fatalf("'%s' should not be a simple ASCII line", line)
+		}
+	}
+}
+
+func TestHanging(t *testing.T) {
+	tests := []struct {
+		s        string
+		limit    int
+		indent   string
+		expected string
+	}{
+		{"- aaaa bbbb cccc", 11, "  ", "- aaaa bbbb\n  cccc"},
+		{"1. aaaa bbbb cccc dddd", 8, "   ", "1. aaaa\n   bbbb\n   cccc\n   dddd"},
+		{"“日本語のテキスト", 9, " ", "“日本語の\n テキスト"},
+		{"- a", 10, "  ", "- a"},
+	}
+
+	for _, test := range tests {
+		s := strings.Join(Hanging(test.s, test.limit, test.indent), "\n")
+
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right wrapping of '%s' (expected: '%s')", s, test.s, test.expected)
+		}
+	}
+}
+
+func TestJustify(t *testing.T) {
+	tests := []struct {
+		s        string
+		limit    int
+		prefix   string
+		expected string
+	}{
+		{"aa bb cc", 10, "", "aa  bb  cc"},
+		{"aa bb cc", 11, "", "aa   bb  cc"},
+		{"- aa bb", 10, "- ", "- aa    bb"},
+		{"  aa bb", 9, "  ", "  aa   bb"},
+		{"aaaa", 10, "", "aaaa"},
+		{"aaaa bbbb", 5, "", "aaaa bbbb"},
+		{"日本 語", 8, "", "日本  語"},
+	}
+
+	for _, test := range tests {
+		s := Justify(test.s, test.limit, test.prefix)
+
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right justification of '%s' (expected: '%s')", s, test.s, test.expected)
+		}
+	}
+}
+
+func TestCenter(t *testing.T) {
+	tests := []struct {
+		s        string
+		limit    int
+		expected string
+	}{
+		{"aa", 6, "  aa"},
+		{"aaa", 6, " aaa"},
+		{"日本", 8, "  日本"},
+		{"aaaaaa", 4, "aaaaaa"},
+	}
+
+	for _, test := range tests {
+		s := Center(test.s, test.limit)
+
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right centering of '%s' (expected: '%s')", s, test.s, test.expected)
+		}
+	}
+}
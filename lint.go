@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/theobori/lueur/gophermap"
+	"github.com/theobori/lueur/lint"
+)
+
+// Exit codes suited for the pre-commit hooks
+const (
+	LintExitCodeSuccess = 0
+	LintExitCodeIssues  = 1
+	LintExitCodeError   = 2
+)
+
+func lintFileFormatFromPath(filePath string, fileFormat gophermap.FileFormat) gophermap.FileFormat {
+	if filepath.Ext(filePath) == ".gph" {
+		return gophermap.FileFormatGPH
+	}
+
+	return fileFormat
+}
+
+func lintFromFilePath(filePath string, fileFormat gophermap.FileFormat, descriptionLimit int) ([]lint.Issue, error) {
+	linter, err := lint.NewLinter(
+		lintFileFormatFromPath(filePath, fileFormat),
+		descriptionLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return linter.Lint(file, filePath)
+}
+
+func writeLintIssues(issues []lint.Issue, outputFormat string) error {
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(issues)
+	case "text":
+		for _, issue := range issues {
+			fmt.Println(issue.String())
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported lint output format: %s", outputFormat)
+	}
+}
+
+// It returns the process exit code
+func processLint(filePaths []string, fileFormat gophermap.FileFormat, descriptionLimit int, outputFormat string) int {
+	if len(filePaths) == 0 {
+		log.Println("at least one file to lint is required")
+
+		return LintExitCodeError
+	}
+
+	issues := []lint.Issue{}
+
+	for _, filePath := range filePaths {
+		fileIssues, err := lintFromFilePath(filePath, fileFormat, descriptionLimit)
+		if err != nil {
+			log.Printf("error: %s with the file: %s\n", err, filePath)
+
+			return LintExitCodeError
+		}
+
+		issues = append(issues, fileIssues...)
+	}
+
+	err := writeLintIssues(issues, outputFormat)
+	if err != nil {
+		log.Println(err)
+
+		return LintExitCodeError
+	}
+
+	if len(issues) > 0 {
+		return LintExitCodeIssues
+	}
+
+	return LintExitCodeSuccess
+}
@@ -0,0 +1,28 @@
+package lint
+
+import "fmt"
+
+type Rule string
+
+const (
+	RuleUnknownItemType    Rule = "unknown-item-type"
+	RuleMissingFields      Rule = "missing-fields"
+	RuleInvalidPort        Rule = "invalid-port"
+	RuleDescriptionTooLong Rule = "description-too-long"
+	RuleURLWithoutPrefix   Rule = "url-without-prefix"
+	RuleTrailingWhitespace Rule = "trailing-whitespace"
+	RuleEmptyDomain        Rule = "empty-domain"
+)
+
+type Issue struct {
+	// Path of the linted file
+	Path string `json:"path"`
+	// Line number, starting at 1
+	Line    int    `json:"line"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (i *Issue) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", i.Path, i.Line, i.Rule, i.Message)
+}
@@ -0,0 +1,261 @@
+package lint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/theobori/lueur/gophermap"
+	"github.com/theobori/lueur/internal/common"
+	"github.com/theobori/lueur/internal/wrap"
+)
+
+// geomyidae replaces this value with the server port
+const gphPortPlaceholder = "port"
+
+type Linter struct {
+	// Maximum amount of characters per description, zero disables the check
+	DescriptionLimit int
+	fileFormat       gophermap.FileFormat
+}
+
+func NewLinter(fileFormat gophermap.FileFormat, descriptionLimit int) (*Linter, error) {
+	if fileFormat == gophermap.FileFormatTxt {
+		return nil, fmt.Errorf(
+			"the file format %s cannot be linted",
+			fileFormat.String(),
+		)
+	}
+
+	return &Linter{
+		DescriptionLimit: descriptionLimit,
+		fileFormat:       fileFormat,
+	}, nil
+}
+
+func (l *Linter) FileFormat() gophermap.FileFormat {
+	return l.fileFormat
+}
+
+// Lint reads every line of the reader and returns the issues found,
+// path is only used to fill the issues
+func (l *Linter) Lint(r io.Reader, path string) ([]Issue, error) {
+	issues := []Issue{}
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		for _, issue := range l.lintLine(line) {
+			issue.Path = path
+			issue.Line = n
+
+			issues = append(issues, issue)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func (l *Linter) lintLine(line string) []Issue {
+	issues := []Issue{}
+
+	// The trailing whitespaces are only reported once, not as a part of
+	// the last field
+	trimmed := strings.TrimRight(line, " \t")
+	if trimmed != line {
+		issues = append(issues, Issue{
+			Rule:    RuleTrailingWhitespace,
+			Message: "the line ends with whitespaces",
+		})
+	}
+	line = trimmed
+
+	switch l.fileFormat {
+	case gophermap.FileFormatGPH:
+		issues = append(issues, l.lintGPHLine(line)...)
+	default:
+		issues = append(issues, l.lintGophermapLine(line)...)
+	}
+
+	return issues
+}
+
+func (l *Linter) lintGophermapLine(line string) []Issue {
+	// Lines without any separator are displayed as text by the servers
+	if !strings.Contains(line, gophermap.DefaultSeparator) {
+		return l.lintDescription(line)
+	}
+
+	fields := strings.Split(line, gophermap.DefaultSeparator)
+	if len(fields) < 4 || fields[0] == "" {
+		return []Issue{{
+			Rule: RuleMissingFields,
+			Message: fmt.Sprintf(
+				"expected 4 tab separated fields, found %d",
+				len(fields),
+			),
+		}}
+	}
+
+	// The item type may be any character
+	_, size := utf8.DecodeRuneInString(fields[0])
+
+	return l.lintFields(
+		fields[0][:size],
+		fields[0][size:],
+		fields[1],
+		fields[2],
+		fields[3],
+	)
+}
+
+func (l *Linter) lintGPHLine(line string) []Issue {
+	// Every other line is displayed as text by geomyidae
+	if !strings.HasPrefix(line, "[") {
+		return l.lintDescription(strings.TrimPrefix(line, "t"))
+	}
+
+	if !strings.HasSuffix(line, "]") {
+		return []Issue{{
+			Rule:    RuleMissingFields,
+			Message: "the link is not closed with ']'",
+		}}
+	}
+
+	fields := splitGPHFields(line[1 : len(line)-1])
+	if len(fields) < 5 {
+		return []Issue{{
+			Rule: RuleMissingFields,
+			Message: fmt.Sprintf(
+				"expected 5 pipe separated fields, found %d",
+				len(fields),
+			),
+		}}
+	}
+
+	return l.lintFields(
+		fields[0],
+		fields[1],
+		fields[2],
+		fields[3],
+		fields[4],
+	)
+}
+
+func (l *Linter) lintFields(
+	itemType string,
+	description string,
+	selector string,
+	domain string,
+	port string,
+) []Issue {
+	issues := []Issue{}
+
+	if len(itemType) != 1 || !gophermap.IsByteItemType(itemType[0]) {
+		issues = append(issues, Issue{
+			Rule:    RuleUnknownItemType,
+			Message: fmt.Sprintf("'%s' is not a known item type", itemType),
+		})
+	}
+
+	issues = append(issues, l.lintDescription(description)...)
+
+	if common.IsURL(selector) && !strings.HasPrefix(selector, "URL:") {
+		issues = append(issues, Issue{
+			Rule: RuleURLWithoutPrefix,
+			Message: fmt.Sprintf(
+				"the selector '%s' looks like an URL but is not prefixed with 'URL:'",
+				selector,
+			),
+		})
+	}
+
+	// The clients do not follow the informational lines, their domain and
+	// their port are placeholders such as "(NULL)" and 0
+	inlineText := gophermap.ItemTypeInlineText
+	if itemType == inlineText.String() {
+		return issues
+	}
+
+	if domain == "" {
+		issues = append(issues, Issue{
+			Rule:    RuleEmptyDomain,
+			Message: "the domain is empty",
+		})
+	}
+
+	if l.fileFormat != gophermap.FileFormatGPH || port != gphPortPlaceholder {
+		issues = append(issues, lintPort(port)...)
+	}
+
+	return issues
+}
+
+func (l *Linter) lintDescription(description string) []Issue {
+	if l.DescriptionLimit <= 0 {
+		return nil
+	}
+
+	// The limit is the word wrap limit, a display width
+	n := wrap.Width(description)
+	if n <= l.DescriptionLimit {
+		return nil
+	}
+
+	return []Issue{{
+		Rule: RuleDescriptionTooLong,
+		Message: fmt.Sprintf(
+			"the description is %d columns wide, the limit is %d",
+			n,
+			l.DescriptionLimit,
+		),
+	}}
+}
+
+func lintPort(port string) []Issue {
+	value, err := strconv.Atoi(port)
+	if err != nil {
+		return []Issue{{
+			Rule:    RuleInvalidPort,
+			Message: fmt.Sprintf("'%s' is not a numeric port", port),
+		}}
+	}
+
+	if value <= 0 || value > 65535 {
+		return []Issue{{
+			Rule:    RuleInvalidPort,
+			Message: fmt.Sprintf("%d is not in the port range 1-65535", value),
+		}}
+	}
+
+	return nil
+}
+
+// Split the fields on the pipes that are not escaped
+func splitGPHFields(s string) []string {
+	fields := []string{}
+	builder := strings.Builder{}
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '|':
+			builder.WriteByte('|')
+			i++
+		case s[i] == '|':
+			fields = append(fields, builder.String())
+			builder.Reset()
+		default:
+			builder.WriteByte(s[i])
+		}
+	}
+
+	return append(fields, builder.String())
+}
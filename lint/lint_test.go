@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+type lintTest struct {
+	source   string
+	expected []Rule
+}
+
+func testLintHelper(t *testing.T, tests []lintTest, fileFormat gophermap.FileFormat) {
+	linter, err := NewLinter(fileFormat, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		issues, err := linter.Lint(strings.NewReader(test.source), "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rules := []Rule{}
+		for _, issue := range issues {
+			rules = append(rules, issue.Rule)
+		}
+
+		if !slices.Equal(rules, test.expected) {
+			t.Fatalf(
+				"'%v' are not the right issues for the source: '%s' (expected: '%v')",
+				rules,
+				test.source,
+				test.expected,
+			)
+		}
+	}
+}
+
+func TestLintGophermap(t *testing.T) {
+	tests := []lintTest{
+		{source: "ihello\t/\tlocalhost\t70\n", expected: []Rule{}},
+		{source: "plain text line\n\n", expected: []Rule{}},
+		{source: "xhello\t/\tlocalhost\t70", expected: []Rule{RuleUnknownItemType}},
+		{source: "ihello\t/\tlocalhost", expected: []Rule{RuleMissingFields}},
+		{source: "1hello\t/\tlocalhost\tseventy", expected: []Rule{RuleInvalidPort}},
+		{source: "1hello\t/\tlocalhost\t70000", expected: []Rule{RuleInvalidPort}},
+		{source: "iWelcome\tfake\t(NULL)\t0", expected: []Rule{}},
+		{source: "ihello\t/\tlocalhost\tseventy", expected: []Rule{}},
+		{source: "ia very long description\t/\tlocalhost\t70", expected: []Rule{RuleDescriptionTooLong}},
+		{source: "a very long plain text line", expected: []Rule{RuleDescriptionTooLong}},
+		{source: "ha\thttps://a.com\ta.com\t443", expected: []Rule{RuleURLWithoutPrefix}},
+		{source: "ha\tURL:https://a.com\ta.com\t443", expected: []Rule{}},
+		{source: "ihello\t/\tlocalhost\t70 ", expected: []Rule{RuleTrailingWhitespace}},
+		{source: "éhello\t/\tlocalhost\t70", expected: []Rule{RuleUnknownItemType}},
+		{source: "1hello\t/\t\t70", expected: []Rule{RuleEmptyDomain}},
+		{source: "ihello\t/\t\t70", expected: []Rule{}},
+		{source: "ihello\t/\tlocalhost\t70\r\n", expected: []Rule{}},
+	}
+
+	testLintHelper(t, tests, gophermap.FileFormatGophermap)
+}
+
+func TestLintGPH(t *testing.T) {
+	tests := []lintTest{
+		{source: "[i|hello|/|localhost|70]\n", expected: []Rule{}},
+		{source: "[1|hello|/|server|port]\n", expected: []Rule{}},
+		{source: "[i|a\\|b|/|localhost|70]", expected: []Rule{}},
+		{source: "plain text line\n", expected: []Rule{}},
+		{source: "[x|hello|/|localhost|70]", expected: []Rule{RuleUnknownItemType}},
+		{source: "[ii|hello|/|localhost|70]", expected: []Rule{RuleUnknownItemType}},
+		{source: "[i|hello|/|localhost]", expected: []Rule{RuleMissingFields}},
+		{source: "[i|hello|/|localhost|70", expected: []Rule{RuleMissingFields}},
+		{source: "[1|hello|/|localhost|-1]", expected: []Rule{RuleInvalidPort}},
+		{source: "[i|Welcome|fake|(NULL)|0]", expected: []Rule{}},
+		{source: "[i|a very long description|/|localhost|70]", expected: []Rule{RuleDescriptionTooLong}},
+		{source: "[h|a|gopher://a.com/1/|a.com|70]", expected: []Rule{RuleURLWithoutPrefix}},
+		{source: "[i|hello|/|localhost|70]\t", expected: []Rule{RuleTrailingWhitespace}},
+		{source: "[1|hello|/||70]", expected: []Rule{RuleEmptyDomain}},
+	}
+
+	testLintHelper(t, tests, gophermap.FileFormatGPH)
+}
+
+func TestNewLinterTxt(t *testing.T) {
+	_, err := NewLinter(gophermap.FileFormatTxt, 80)
+	if err == nil {
+		t.Fatal("the txt file format should not be lintable")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Environment variable holding the arguments of main run by the test binary
+const testMainArgsEnv = "LUEUR_TEST_MAIN_ARGS"
+
+func TestLintExitCodes(t *testing.T) {
+	args, isMain := os.LookupEnv(testMainArgsEnv)
+	if isMain {
+		os.Args = append([]string{"lueur"}, strings.Fields(args)...)
+		main()
+		return
+	}
+
+	directory := t.TempDir()
+	path := filepath.Join(directory, "index.gph")
+
+	err := os.WriteFile(path, []byte("[x|hello|/|localhost|70]\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args     string
+		expected int
+	}{
+		{"-lint " + path, LintExitCodeIssues},
+		{"-lint " + filepath.Join(directory, "missing.gph"), LintExitCodeError},
+		{"-lint -config " + filepath.Join(directory, "missing.toml") + " " + path, LintExitCodeError},
+		{"-lint -file-format unknown " + path, LintExitCodeError},
+		{"-lint -include [ " + path, LintExitCodeError},
+	}
+
+	for _, test := range tests {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestLintExitCodes$")
+		cmd.Dir = directory
+		cmd.Env = append(os.Environ(), testMainArgsEnv+"="+test.args)
+
+		err := cmd.Run()
+
+		var exitError *exec.ExitError
+		if !errors.As(err, &exitError) {
+			t.Fatalf("'lueur %s' should fail: %v", test.args, err)
+		}
+
+		if exitError.ExitCode() != test.expected {
+			t.Fatalf(
+				"'lueur %s' exited with %d (expected: %d)",
+				test.args,
+				exitError.ExitCode(),
+				test.expected,
+			)
+		}
+	}
+}
@@ -1,16 +1,18 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"os"
-	"path/filepath"
+	"reflect"
 	"strings"
 
+	"github.com/theobori/lueur/config"
 	"github.com/theobori/lueur/gophermap"
+	"github.com/theobori/lueur/internal/hyphen"
 	"github.com/theobori/lueur/walker"
 )
 
@@ -19,121 +21,302 @@ const (
 	DirectoryOutputName = DirectoryPrefix + "-" + "output"
 )
 
-func processFromSource(source []byte, options *walker.Options) (string, error) {
-	w := walker.NewWalkerWithOptions(source, options)
+// Version of lueur, the directory outputs are converted again when it
+// changes
+var Version = "0.0.1"
 
-	output, err := w.WalkFromRoot()
-	if err != nil {
-		return "", err
+// Repeatable string flag
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+func processFromSource(
+	source []byte,
+	inputFormat walker.InputFormat,
+	options *walker.Options,
+	writer io.Writer,
+) error {
+	var (
+		w   *walker.Walker
+		err error
+	)
+
+	switch inputFormat {
+	case walker.InputFormatHTML:
+		w, err = walker.NewHTMLDocumentWalkerWithOptions(source, options)
+		if err != nil {
+			return err
+		}
+	default:
+		w = walker.NewWalkerWithOptions(source, options)
 	}
 
-	return output, nil
+	return w.WalkTo(writer)
 }
 
-func processFromFilePath(filePath string, options *walker.Options) (string, error) {
-	source, err := os.ReadFile(filePath)
+// The Markdown documents are split into pages with a split heading level,
+// the name is the document path without extension. The long code blocks
+// may be written into their own pages.
+func processPagesFromSource(
+	source []byte,
+	inputFormat walker.InputFormat,
+	options *walker.Options,
+	name string,
+) ([]walker.Page, error) {
+	if inputFormat == walker.InputFormatMarkdown {
+		return walker.NewWalkerWithOptions(source, options).WalkPages(name)
+	}
+
+	w, err := walker.NewHTMLDocumentWalkerWithOptions(source, options)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return processFromSource(source, options)
+	return w.WalkDocument(name)
 }
 
-// This function should work like a transaction. It means, it will create a temporary directory
-// and try to process every Markdown files found. Everything has to succeed,
-// otherwise the temporary directory will be removed.
-//
-// TODO: Improve end user error informations
-func processFromDirectoryPath(directoryPath string, outputDirectoryPath string, options *walker.Options) error {
-	// Creating the temporary directory
-	tDir, err := os.MkdirTemp("", DirectoryPrefix)
+func processFromFilePath(
+	filePath string,
+	inputFormat walker.InputFormat,
+	options *walker.Options,
+	writer io.Writer,
+) error {
+	source, err := os.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
 
-	defer os.RemoveAll(tDir)
+	return processFromSource(source, inputFormat, options, writer)
+}
 
-	err = filepath.WalkDir(directoryPath, func(path string, d fs.DirEntry, err error) error {
-		// Skip directories
-		if d.IsDir() {
-			return nil
-		}
-		// Skip non Markdown file extension
-		ext := filepath.Ext(path)
-		if ext != ".md" && ext != ".markdown" {
-			return nil
-		}
+// Only the sources changed since the last build are converted again, the
+// outputs are tracked with a manifest written into the output directory.
+// With dryRun, the changes are written to writer instead of being applied,
+// showDiff also writes their unified diffs.
+func processFromDirectoryPath(
+	cfg *config.Config,
+	dryRun bool,
+	showDiff bool,
+	writer io.Writer,
+) error {
+	plan, err := newBuildPlan(cfg)
+	if err != nil {
+		return err
+	}
+	defer plan.close()
 
-		destinationDirPath := filepath.Join(tDir, filepath.Dir(path))
-		destinationFilename := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + "." + options.FileFormat().String()
-		// Create the temporary destination directory
-		err = os.MkdirAll(destinationDirPath, os.ModePerm)
+	if dryRun || showDiff {
+		err = plan.preview(writer, showDiff)
 		if err != nil {
 			return err
 		}
+	}
 
-		// Process the file path
-		output, err := processFromFilePath(path, options)
-		if err != nil {
-			return fmt.Errorf("error: %s with the file: %s", err, path)
-		}
+	if dryRun {
+		return nil
+	}
 
-		destinationFilePath := filepath.Join(destinationDirPath, destinationFilename)
-		destinationFile, err := os.Create(destinationFilePath)
-		if err != nil {
-			return err
-		}
+	err = plan.apply()
+	if err != nil {
+		return err
+	}
 
-		_, err = destinationFile.WriteString(output)
-		if err != nil {
-			return err
-		}
+	log.Printf(
+		"The directory %s is up to date, %d file(s) changed and %d unchanged",
+		cfg.Output,
+		len(plan.actions),
+		plan.upToDate,
+	)
 
-		destinationFile.Close()
+	return nil
+}
 
-		log.Printf("The file %s has been written\n", destinationFilePath)
+func processFromStdin(
+	inputFormat walker.InputFormat,
+	options *walker.Options,
+	writer io.Writer,
+) error {
+	source, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
 
-		return nil
+	return processFromSource(source, inputFormat, options, writer)
+}
+
+// Only the flags explicitly set override the configuration file
+func isFlagSet(name string) bool {
+	isSet := false
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			isSet = true
+		}
 	})
+
+	return isSet
+}
+
+// A directory is converted with -directory, or with the input directory of
+// the configuration unless -file or -input-format ask for another input
+func isDirectoryMode(
+	filePath string,
+	directoryPath string,
+	inputFormatString string,
+	cfg *config.Config,
+) bool {
+	if filePath != "" {
+		return false
+	}
+
+	return directoryPath != "" || (inputFormatString == "" && cfg.Input != "")
+}
+
+// The input format is detected from the file extension when it is not set
+func inputFormatFromString(s string, filePath string, cfg *config.Config) (walker.InputFormat, error) {
+	if s != "" {
+		return walker.NewInputFormatFromString(s)
+	}
+
+	inputFormat, _ := cfg.InputFormat(filePath)
+
+	return inputFormat, nil
+}
+
+// The options of a single document written to the standard output, the
+// options writing several files can only be used in directory mode
+func singleDocumentOptions(cfg *config.Config) (*walker.Options, error) {
+	options, err := cfg.WalkerOptions(".")
+	if err != nil {
+		return nil, err
+	}
+
+	if options.SplitHeadingLevel() > 0 {
+		return nil, fmt.Errorf("split-heading-level can only be used in directory mode")
+	}
+
+	if options.CodeBlockFileMinLines() > 0 {
+		return nil, fmt.Errorf("code-block-file-min-lines can only be used in directory mode")
+	}
+
+	return options, nil
+}
+
+func processFromFilePathWithConfig(
+	filePath string,
+	inputFormat walker.InputFormat,
+	cfg *config.Config,
+	writer io.Writer,
+) error {
+	options, err := singleDocumentOptions(cfg)
 	if err != nil {
 		return err
 	}
 
-	directoryName := filepath.Join(tDir, directoryPath)
-	err = os.Rename(directoryName, outputDirectoryPath)
+	return processFromFilePath(filePath, inputFormat, options, writer)
+}
+
+func processFromStdinWithConfig(
+	inputFormat walker.InputFormat,
+	cfg *config.Config,
+	writer io.Writer,
+) error {
+	options, err := singleDocumentOptions(cfg)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("The directory %s has been created and contains your files", outputDirectoryPath)
+	return processFromStdin(inputFormat, options, writer)
+}
 
-	return nil
+// Every heading style flag is written as "level=styles"
+func headingStylesFromFlag(values stringsFlag) (map[string]string, error) {
+	headingStyles := map[string]string{}
+
+	for _, value := range values {
+		level, styles, hasSeparator := strings.Cut(value, "=")
+		if !hasSeparator {
+			return nil, fmt.Errorf("invalid heading style: %s, expected \"level=styles\"", value)
+		}
+
+		headingStyles[level] = styles
+	}
+
+	return headingStyles, nil
 }
 
-func processFromStdin(options *walker.Options) (string, error) {
-	source, err := io.ReadAll(os.Stdin)
+// The option flags are bound to the options fields sharing their TOML key,
+// only the flags explicitly set override the configuration file
+func optionsFromFlags(flagOptions config.Options, headingStyles stringsFlag) (config.Options, error) {
+	o := config.Options{}
+	var err error
+
+	values := reflect.ValueOf(&o).Elem()
+	flagValues := reflect.ValueOf(flagOptions)
+	fields := values.Type()
+
+	for i := range fields.NumField() {
+		name := fields.Field(i).Tag.Get("toml")
+		if !flagValues.Field(i).IsNil() && isFlagSet(name) {
+			values.Field(i).Set(flagValues.Field(i))
+		}
+	}
+
+	if isFlagSet("heading-style") {
+		o.HeadingStyles, err = headingStylesFromFlag(headingStyles)
+	}
+
+	return o, err
+}
+
+func loadConfig(configPath string) (*config.Config, error) {
+	if configPath != "" {
+		return config.Load(configPath)
+	}
+
+	// The configuration file is optional
+	_, err := os.Stat(config.DefaultFileName)
 	if err != nil {
-		return "", err
+		return config.NewDefaultConfig(), nil
 	}
 
-	return processFromSource(source, options)
+	return config.Load(config.DefaultFileName)
 }
 
 func main() {
 	var (
-		err                     error
-		filePath                string
-		directoryPath           string
-		outputDirectoryPath     string
-		referencePositionString string
-		fileFormatString        string
-		wordWrapLimit           int
-		domain                  string
-		port                    int
-		writeFancyHeader        bool
-		pathPrefix              string
+		err                 error
+		configPath          string
+		filePath            string
+		directoryPath       string
+		outputDirectoryPath string
+		lintMode            bool
+		lintOutputFormat    string
+		includes            stringsFlag
+		excludes            stringsFlag
+		extensions          stringsFlag
+		hidden              bool
+		inputFormatString   string
+		dryRun              bool
+		showDiff            bool
+		headingStyles       stringsFlag
+		flagOptions         config.Options
 	)
 
+	flag.StringVar(
+		&configPath,
+		"config",
+		"",
+		"Read the options from a TOML configuration file, \""+config.DefaultFileName+"\" is read by default if it exists, the flags take precedence",
+	)
 	flag.StringVar(
 		&filePath,
 		"file",
@@ -141,7 +324,17 @@ func main() {
 		"Read input from a file",
 	)
 	flag.StringVar(
-		&pathPrefix,
+		&inputFormatString,
+		"input-format",
+		"",
+		"Input format of -file and of the standard input (\"markdown\", \"html\"), it is detected from the file extension by default, the standard input is converted with it even when the configuration sets an input directory",
+	)
+	flagOptions.DropHTMLLayout = flag.Bool(
+		"drop-html-layout",
+		false,
+		"Drop the <nav>, <header>, <footer> and <aside> elements of the HTML documents",
+	)
+	flagOptions.PathPrefix = flag.String(
 		"path-prefix",
 		"",
 		"Prefix applied to the reference paths",
@@ -158,82 +351,297 @@ func main() {
 		DirectoryOutputName,
 		"It specifies an output directory name when -directory-path is used",
 	)
-	flag.StringVar(
-		&domain,
+	flag.Var(
+		&includes,
+		"include",
+		"Glob pattern of the files to convert in directory mode, it can be repeated",
+	)
+	flag.Var(
+		&excludes,
+		"exclude",
+		"Glob pattern of the files and directories to skip in directory mode, it can be repeated (also read from \""+config.IgnoreFileName+"\")",
+	)
+	flag.Var(
+		&extensions,
+		"extension",
+		"File extension to convert in directory mode with its input format as \"extension=format\" (\"markdown\", \"html\"), it can be repeated",
+	)
+	flag.BoolVar(
+		&hidden,
+		"hidden",
+		false,
+		"Also walk the hidden directories in directory mode",
+	)
+	flag.BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"List the files that would be created, updated or deleted in directory mode without writing anything",
+	)
+	flag.BoolVar(
+		&showDiff,
+		"diff",
+		false,
+		"Write the unified diffs of the changed files in directory mode, it can be combined with -dry-run",
+	)
+	flagOptions.Domain = flag.String(
 		"domain",
 		"",
 		"Gopher domain",
 	)
-	flag.IntVar(
-		&port,
+	flagOptions.Port = flag.Int(
 		"port",
 		gophermap.DefaultGopherPort,
 		"Gopher port",
 	)
-	flag.IntVar(
-		&wordWrapLimit,
+	flagOptions.WordWrapLimit = flag.Int(
 		"word-wrap-limit",
 		80,
 		"Word wrap limit",
 	)
-	flag.BoolVar(
-		&writeFancyHeader,
+	flagOptions.FancyHeader = flag.Bool(
 		"fancy-header",
 		false,
 		"Write fancy headers (with hashtags as prefix)",
 	)
-	flag.StringVar(
-		&fileFormatString,
+	flagOptions.TOC = flag.Bool(
+		"toc",
+		false,
+		"Write a table of contents at the top of the Markdown documents or in place of a \""+walker.TableOfContentsMarker+"\" paragraph",
+	)
+	flagOptions.TOCMaxDepth = flag.Int(
+		"toc-max-depth",
+		0,
+		"Deepest heading level of the table of contents, relative to the highest level used by the document, every level with 0",
+	)
+	flagOptions.NumberedHeadings = flag.Bool(
+		"numbered-headings",
+		false,
+		"Prefix the headings with their section number, also written in the table of contents",
+	)
+	flagOptions.SplitHeadingLevel = flag.Int(
+		"split-heading-level",
+		0,
+		"Split the Markdown documents into linked pages at the headings of this level, only in directory mode, they are not split with 0",
+	)
+	flag.Var(
+		&headingStyles,
+		"heading-style",
+		"Comma separated styles of a heading level as \"level=styles\" (\"plain\", \"uppercase\", \"underline\", \"box\", \"center\", \"figlet\"), it can be repeated",
+	)
+	flagOptions.HeadingBlankLinesBefore = flag.Int(
+		"heading-blank-lines-before",
+		0,
+		"Extra empty lines written before the headings",
+	)
+	flagOptions.HeadingBlankLinesAfter = flag.Int(
+		"heading-blank-lines-after",
+		0,
+		"Extra empty lines written after the headings",
+	)
+	flagOptions.TextAlignment = flag.String(
+		"text-alignment",
+		"left",
+		"Alignment of the text lines within the word wrap limit (\"left\", \"justify\", \"center\"), the code blocks are kept to the left",
+	)
+	flagOptions.BlockQuoteStyle = flag.String(
+		"blockquote-style",
+		"typographic",
+		"Rendering of the block quotes (\"typographic\", \"email\", \"bar\")",
+	)
+	flagOptions.QuoteLanguage = flag.String(
+		"quote-language",
+		walker.DefaultQuoteLanguage,
+		"Language of the typographic quotation marks (\"en\", \"de\", \"es\", \"fr\", \"it\"), the \"lang\" of the document front matter takes precedence",
+	)
+	flagOptions.ThematicBreakStyle = flag.String(
+		"thematic-break-style",
+		"none",
+		"Rendering of the thematic breaks and of the HTML <hr> elements (\"none\", \"dashes\", \"box\", \"ornament\"), the lines are as wide as the word wrap limit",
+	)
+	flagOptions.ThematicBreakOrnament = flag.String(
+		"thematic-break-ornament",
+		walker.DefaultThematicBreakOrnament,
+		"Centered text of the ornament thematic breaks",
+	)
+	flagOptions.CodeBlockCaption = flag.Bool(
+		"code-block-caption",
+		false,
+		"Write the language of the fenced code blocks as their caption",
+	)
+	flagOptions.CodeBlockFrame = flag.String(
+		"code-block-frame",
+		"none",
+		"Frame of the code blocks (\"none\", \"box\", \"indent\"), the framed code lines are never wrapped",
+	)
+	flagOptions.CodeBlockLineNumbers = flag.Bool(
+		"code-block-line-numbers",
+		false,
+		"Prefix the code block lines with their number",
+	)
+	flagOptions.CodeBlockFileMinLines = flag.Int(
+		"code-block-file-min-lines",
+		0,
+		"Write the code blocks of at least this amount of lines into their own text file linked from the document, only in directory mode, 0 disables it",
+	)
+	flagOptions.CodeBlockFilePreview = flag.Int(
+		"code-block-file-preview",
+		0,
+		"Amount of code lines kept above the link of a code block file",
+	)
+	flagOptions.CodeSpanStyle = flag.String(
+		"code-span-style",
+		"plain",
+		"Style of the inline code spans (\"plain\", \"backticks\", \"asterisks\", \"underscores\", \"slashes\", \"uppercase\", \"ansi\")",
+	)
+	flagOptions.EmphasisStyle = flag.String(
+		"emphasis-style",
+		"plain",
+		"Style of the emphasized text (\"plain\", \"backticks\", \"asterisks\", \"underscores\", \"slashes\", \"uppercase\", \"ansi\")",
+	)
+	flagOptions.StrongStyle = flag.String(
+		"strong-style",
+		"plain",
+		"Style of the strong text (\"plain\", \"backticks\", \"asterisks\", \"underscores\", \"slashes\", \"uppercase\", \"ansi\")",
+	)
+	flagOptions.DeduplicateReferences = flag.Bool(
+		"deduplicate-references",
+		false,
+		"Write the references of a destination once, the \"after-all\" ones keep their first number",
+	)
+	flagOptions.ReferenceSectionLevel = flag.Int(
+		"reference-section-level",
+		walker.DefaultReferenceSectionLevel,
+		"Heading level ending the sections of the \"after-section\" references, the higher level headings end them too",
+	)
+	flagOptions.RestartReferenceNumbering = flag.Bool(
+		"restart-reference-numbering",
+		false,
+		"Number the \"after-section\" references from 1 in every section",
+	)
+	flagOptions.Hyphenation = flag.Bool(
+		"hyphenation",
+		false,
+		"Hyphenate the wrapped words of the text, the links, the code spans and the headings are never hyphenated",
+	)
+	flagOptions.HyphenationLanguage = flag.String(
+		"hyphenation-language",
+		walker.DefaultHyphenationLanguage,
+		"Language of the hyphenation (\""+strings.Join(hyphen.Languages(), "\", \"")+"\"), the \"lang\" of the document front matter takes precedence",
+	)
+	flagOptions.FileFormat = flag.String(
 		"file-format",
 		"gophermap",
 		"Output file format (\"gophermap\", \"gph\", \"txt\")",
 	)
-	flag.StringVar(
-		&referencePositionString,
+	flagOptions.ReferencePosition = flag.String(
 		"reference-position",
 		"after-block",
-		"Used to control where the references are outputed (\"after-block\", \"after-all\")",
+		"Used to control where the references are outputed (\"after-block\", \"after-all\", \"bibliography\", \"after-section\")",
+	)
+
+	flag.BoolVar(
+		&lintMode,
+		"lint",
+		false,
+		"Lint the gophermap and gph files given as arguments instead of converting (\".gph\" files are linted as gph, the others use -file-format)",
+	)
+	flag.StringVar(
+		&lintOutputFormat,
+		"lint-output",
+		"text",
+		"Lint issues output format (\"text\", \"json\")",
 	)
 
 	flag.Parse()
 
-	referencePosition, err := walker.NewOutputPositionFromString(referencePositionString)
+	// The lint issues exit with the code 1, the errors must not be taken
+	// for them
+	fatal := log.Fatalln
+	if lintMode {
+		fatal = func(v ...any) {
+			log.Println(v...)
+			os.Exit(LintExitCodeError)
+		}
+	}
+
+	cfg, err := loadConfig(configPath)
 	if err != nil {
-		log.Fatalln(err)
+		fatal(err)
 	}
 
-	fileFormat, err := gophermap.NewFileFormatFromString(fileFormatString)
+	cfg.Flags, err = optionsFromFlags(flagOptions, headingStyles)
 	if err != nil {
-		log.Fatalln(err)
+		fatal(err)
 	}
 
-	options, err := walker.NewOptions(
-		wordWrapLimit,
-		referencePosition,
-		domain,
-		port,
-		writeFancyHeader,
-		fileFormat,
-		pathPrefix,
-	)
+	if directoryPath != "" {
+		cfg.Input = directoryPath
+	}
+
+	if isFlagSet("output-directory") || cfg.Output == "" {
+		cfg.Output = outputDirectoryPath
+	}
+
+	if isFlagSet("hidden") {
+		cfg.Hidden = hidden
+	}
+
+	err = cfg.AddInclude(includes...)
+	if err != nil {
+		fatal(err)
+	}
+
+	err = cfg.AddExclude(excludes...)
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, extension := range extensions {
+		err = cfg.SetExtension(extension)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	if lintMode {
+		o := cfg.ResolvedOptions(".")
+
+		fileFormat, err := gophermap.NewFileFormatFromString(*o.FileFormat)
+		if err != nil {
+			fatal(err)
+		}
+
+		os.Exit(processLint(flag.Args(), fileFormat, *o.WordWrapLimit, lintOutputFormat))
+	}
+
+	inputFormat, err := inputFormatFromString(inputFormatString, filePath, cfg)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	var output string
+	// The build is only previewed when converting a directory
+	isDirectoryMode := isDirectoryMode(filePath, directoryPath, inputFormatString, cfg)
+	if (dryRun || showDiff) && !isDirectoryMode {
+		log.Fatalln("-dry-run and -diff can only be used in directory mode")
+	}
+
+	// The output is streamed to the standard output
+	writer := bufio.NewWriter(os.Stdout)
 	if filePath != "" {
-		output, err = processFromFilePath(filePath, options)
-	} else if directoryPath != "" {
-		err = processFromDirectoryPath(directoryPath, outputDirectoryPath, options)
+		err = processFromFilePathWithConfig(filePath, inputFormat, cfg, writer)
+	} else if isDirectoryMode {
+		err = processFromDirectoryPath(cfg, dryRun, showDiff, writer)
 	} else {
-		output, err = processFromStdin(options)
+		err = processFromStdinWithConfig(inputFormat, cfg, writer)
 	}
 
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	if output != "" {
-		fmt.Println(output)
+	err = writer.Flush()
+	if err != nil {
+		log.Fatalln(err)
 	}
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/theobori/lueur/config"
+	"github.com/theobori/lueur/walker"
+)
+
+func TestStdinWithConfigInput(t *testing.T) {
+	directory := t.TempDir()
+
+	err := os.WriteFile(
+		filepath.Join(directory, config.DefaultFileName),
+		[]byte("input = \"src\"\nfile-format = \"txt\"\nreference-position = \"after-all\"\n"),
+		0o644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(directory)
+
+	cfg, err := loadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The configured input directory is converted by default
+	if !isDirectoryMode("", "", "", cfg) {
+		t.Fatal("the configured input directory should be converted")
+	}
+
+	// An explicit input format converts the standard input
+	if isDirectoryMode("", "", "markdown", cfg) {
+		t.Fatal("the standard input should be converted with -input-format")
+	}
+
+	if isDirectoryMode("a.md", "", "", cfg) || !isDirectoryMode("", "docs", "markdown", cfg) {
+		t.Fatal("-file and -directory should take precedence")
+	}
+
+	stdin, err := os.CreateTemp(directory, "stdin-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+
+	_, err = stdin.WriteString("Hello world\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = stdin.Seek(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previousStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = previousStdin }()
+
+	builder := strings.Builder{}
+
+	err = processFromStdinWithConfig(walker.InputFormatMarkdown, cfg, &builder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The configured options are applied, a text file is written
+	if builder.String() != "\nHello world\n" {
+		t.Fatalf("'%s' is not the converted standard input", builder.String())
+	}
+}
+
+func TestSingleDocumentOptions(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	domain := "example.com"
+	cfg.Flags.Domain = &domain
+	level := 2
+	cfg.Flags.SplitHeadingLevel = &level
+
+	// The pages are only written in directory mode
+	_, err := singleDocumentOptions(cfg)
+	if err == nil {
+		t.Fatal("split-heading-level should be rejected for a single document")
+	}
+
+	level = 0
+	minLines := 30
+	cfg.Flags.CodeBlockFileMinLines = &minLines
+
+	// Nor the code block files
+	_, err = singleDocumentOptions(cfg)
+	if err == nil {
+		t.Fatal("code-block-file-min-lines should be rejected for a single document")
+	}
+
+	minLines = 0
+
+	_, err = singleDocumentOptions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
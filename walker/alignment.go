@@ -0,0 +1,52 @@
+package walker
+
+import "fmt"
+
+type TextAlignment int
+
+const (
+	// The lines are aligned to the left
+	TextAlignmentLeft TextAlignment = iota
+	// The spaces of the wrapped lines are distributed so they fill the
+	// word wrap limit, except the last line of every paragraph
+	TextAlignmentJustify
+	// The lines are centered within the word wrap limit
+	TextAlignmentCenter
+)
+
+func NewTextAlignmentFromString(s string) (TextAlignment, error) {
+	switch s {
+	case "left":
+		return TextAlignmentLeft, nil
+	case "justify":
+		return TextAlignmentJustify, nil
+	case "center":
+		return TextAlignmentCenter, nil
+	default:
+		return TextAlignmentLeft, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+func (a TextAlignment) String() string {
+	switch a {
+	case TextAlignmentLeft:
+		return "left"
+	case TextAlignmentJustify:
+		return "justify"
+	case TextAlignmentCenter:
+		return "center"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
+
+// The soft line breaks of the justified and centered paragraphs are
+// spaces, so their lines are aligned as a whole and not per source line
+func (w *Walker) softLineBreak() string {
+	if w.options.TextAlignment == TextAlignmentLeft {
+		return "\n"
+	}
+
+	return " "
+}
@@ -0,0 +1,138 @@
+package walker
+
+import "testing"
+
+func TestWalkTextAlignment(t *testing.T) {
+	source := `Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed laoreet eros.
+
+    code  block
+
+- Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+`
+
+	tests := []struct {
+		alignment TextAlignment
+		comp      comparable
+	}{
+		{
+			alignment: TextAlignmentLeft,
+			comp: comparable{
+				source: source,
+				expected: `
+Lorem ipsum dolor sit amet, consectetur
+adipiscing elit. Sed laoreet eros.
+
+code  block
+
+- Lorem ipsum dolor sit amet, consectetur
+  adipiscing elit.
+`,
+			},
+		},
+		{
+			alignment: TextAlignmentJustify,
+			comp: comparable{
+				source: source,
+				expected: `
+Lorem   ipsum  dolor  sit  amet,  consectetur
+adipiscing elit. Sed laoreet eros.
+
+code  block
+
+- Lorem  ipsum  dolor  sit  amet, consectetur
+  adipiscing elit.
+`,
+			},
+		},
+		{
+			alignment: TextAlignmentCenter,
+			comp: comparable{
+				source: source,
+				expected: `
+   Lorem ipsum dolor sit amet, consectetur
+     adipiscing elit. Sed laoreet eros.
+
+code  block
+
+  - Lorem ipsum dolor sit amet, consectetur
+               adipiscing elit.
+`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.TextAlignment = test.alignment
+
+		testComparableHelper(t, test.comp, options)
+	}
+}
+
+func TestWalkTextAlignmentSoftLineBreaks(t *testing.T) {
+	source := `Lorem ipsum dolor
+sit amet, consectetur adipiscing elit. Sed
+laoreet eros.
+`
+
+	tests := []struct {
+		alignment TextAlignment
+		expected  string
+	}{
+		{
+			alignment: TextAlignmentLeft,
+			expected: `
+Lorem ipsum dolor
+sit amet, consectetur adipiscing elit. Sed
+laoreet eros.
+`,
+		},
+		{
+			alignment: TextAlignmentJustify,
+			expected: `
+Lorem   ipsum  dolor  sit  amet,  consectetur
+adipiscing elit. Sed laoreet eros.
+`,
+		},
+		{
+			alignment: TextAlignmentCenter,
+			expected: `
+   Lorem ipsum dolor sit amet, consectetur
+     adipiscing elit. Sed laoreet eros.
+`,
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.TextAlignment = test.alignment
+
+		testComparableHelper(t, comparable{source: source, expected: test.expected}, options)
+	}
+}
+
+func TestWalkHTMLCenter(t *testing.T) {
+	tests := []comparable{
+		{
+			source: "<center>Title</center>\n",
+			expected: `
+                    Title
+`,
+		},
+		{
+			source: "<p align=\"center\">Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>\n",
+			expected: `
+   Lorem ipsum dolor sit amet, consectetur
+              adipiscing elit.
+`,
+		},
+		{
+			source: "<p>Title</p>\n",
+			expected: `
+Title
+`,
+		},
+	}
+
+	testComparableMultipleHelper(t, tests, testTxtOptions(t))
+}
@@ -0,0 +1,171 @@
+package walker
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Heading of the references written as a bibliography
+const BibliographyTitle = "Links"
+
+// A Markdown link reference definition, such as [label]: destination "Title"
+type linkDefinition struct {
+	label       string
+	destination string
+	title       string
+}
+
+// Key of the link reference definitions in their source order, the parser
+// context only keeps them by label
+var linkDefinitionsKey = parser.NewContextKey()
+
+// Parser context recording the link reference definitions as they are
+// added
+type linkDefinitionsContext struct {
+	parser.Context
+}
+
+func (c linkDefinitionsContext) AddReference(reference parser.Reference) {
+	references, _ := c.Get(linkDefinitionsKey).([]parser.Reference)
+	c.Set(linkDefinitionsKey, append(references, reference))
+
+	c.Context.AddReference(reference)
+}
+
+// Paragraph transformer extracting the link reference definitions before
+// the goldmark one, the paragraphs are transformed in their source order
+type linkDefinitionsTransformer struct{}
+
+// Priority of the transformer, the goldmark one has the priority 100
+const linkDefinitionsTransformerPriority = 99
+
+func (t linkDefinitionsTransformer) Transform(node *ast.Paragraph, reader text.Reader, pc parser.Context) {
+	parser.LinkReferenceParagraphTransformer.Transform(node, reader, linkDefinitionsContext{pc})
+}
+
+// The link reference definitions in their source order, the first
+// definition of a label is kept like the parser does
+func linkDefinitions(pc parser.Context) []linkDefinition {
+	references, _ := pc.Get(linkDefinitionsKey).([]parser.Reference)
+
+	definitions := []linkDefinition{}
+	labels := map[string]bool{}
+
+	for _, reference := range references {
+		key := util.ToLinkReference(reference.Label())
+		if labels[key] {
+			continue
+		}
+		labels[key] = true
+
+		definitions = append(definitions, linkDefinition{
+			label:       string(reference.Label()),
+			destination: string(reference.Destination()),
+			title:       string(reference.Title()),
+		})
+	}
+
+	return definitions
+}
+
+// The definition of a reference label, the references are only grouped by
+// label in a bibliography
+func (w *Walker) linkDefinition(label string) (linkDefinition, bool) {
+	if label == "" || w.options.ReferencePosition() != Bibliography {
+		return linkDefinition{}, false
+	}
+
+	for _, definition := range w.definitions {
+		if util.ToLinkReference([]byte(definition.label)) == label {
+			return definition, true
+		}
+	}
+
+	return linkDefinition{}, false
+}
+
+// Node attribute holding the label of a reference link or image
+var linkReferenceAttribute = []byte("lueur-reference")
+
+// Parser context recording the last link reference definition found
+type linkReferenceContext struct {
+	parser.Context
+	label string
+}
+
+func (c *linkReferenceContext) Reference(label string) (parser.Reference, bool) {
+	reference, isDefined := c.Context.Reference(label)
+	if isDefined {
+		c.label = label
+	}
+
+	return reference, isDefined
+}
+
+// Link parser recording the label of the reference links and images, the
+// goldmark nodes only keep the destination and the title of their definition
+type linkReferenceParser struct {
+	parser.InlineParser
+}
+
+func (p linkReferenceParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	ctx := &linkReferenceContext{Context: pc}
+
+	node := p.InlineParser.Parse(parent, block, ctx)
+	if node != nil && ctx.label != "" {
+		node.SetAttribute(linkReferenceAttribute, ctx.label)
+	}
+
+	return node
+}
+
+// The default goldmark parser with the link parser recording the reference
+// labels
+func newMarkdownParser() parser.Parser {
+	inlineParsers := parser.DefaultInlineParsers()
+
+	for i, v := range inlineParsers {
+		inlineParser := v.Value.(parser.InlineParser)
+		if slices.Contains(inlineParser.Trigger(), '[') {
+			inlineParsers[i].Value = linkReferenceParser{inlineParser}
+		}
+	}
+
+	return parser.NewParser(
+		parser.WithBlockParsers(parser.DefaultBlockParsers()...),
+		parser.WithInlineParsers(inlineParsers...),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+	)
+}
+
+// Label of a reference link or image, empty for the other nodes
+func linkReferenceLabel(node ast.Node) string {
+	label, _ := node.Attribute(linkReferenceAttribute)
+	s, _ := label.(string)
+
+	return s
+}
+
+// The cited definitions in their source order, followed by the other
+// references, under a heading
+func (w *Walker) bibliographyString() string {
+	builder := strings.Builder{}
+
+	for _, line := range w.wrapText("\n" + w.headingText(BibliographyTitle, 2)) {
+		builder.WriteString(w.renderer.TextLine(line) + "\n")
+	}
+
+	for _, definition := range w.definitions {
+		line, isCited := w.ctx.CitedDefinitions[definition.label]
+		if isCited {
+			builder.WriteString(w.renderer.ReferenceLine(&line) + "\n")
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,96 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+func TestWalkBibliography(t *testing.T) {
+	localOptions := *testOptions
+
+	err := localOptions.SetReferencePositionAndFileFormat(Bibliography, gophermap.FileFormatGophermap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := `Read [the spec][cm], the [goldmark] parser and [the spec again][CM].
+
+See [inline](https://a.org).
+
+[goldmark]: https://github.com/yuin/goldmark
+[cm]: https://spec.commonmark.org "CommonMark"
+[unused]: https://x.org
+`
+
+	// The definitions are written in their source order, once
+	testComparableHelper(t, comparable{
+		source: source,
+		expected: testEmptyGophermapLineString +
+			"iRead (the spec)[cm], the (goldmark)[goldmark] parser and (the spec again)[cm].\t/\tlocalhost\t70\n" +
+			testEmptyGophermapLineString +
+			"iSee (inline)[1].\t/\tlocalhost\t70\n" +
+			testEmptyGophermapLineString +
+			"iLinks\t/\tlocalhost\t70\n" +
+			"h[goldmark] goldmark\tURL:https://github.com/yuin/goldmark\tgithub.com\t443\n" +
+			"h[cm] CommonMark: the spec\tURL:https://spec.commonmark.org\tspec.commonmark.org\t443\n" +
+			"h[1] inline\tURL:https://a.org\ta.org\t443\n",
+	}, &localOptions)
+
+	// Nothing is written without references
+	testComparableHelper(t, comparable{
+		source:   "a\n",
+		expected: testEmptyGophermapLineString + "ia\t/\tlocalhost\t70\n",
+	}, &localOptions)
+}
+
+func TestWalkBibliographyTxt(t *testing.T) {
+	source := "Read [the spec][cm].\n\n[cm]: https://spec.commonmark.org \"CommonMark\"\n"
+
+	// The title is written with the destination
+	testComparableHelper(t, comparable{
+		source: source,
+		expected: "\nRead (the spec)[cm].\n\nLinks\n" +
+			"[cm] CommonMark: https://spec.commonmark.org\n",
+	}, testBibliographyTxtOptions(t))
+}
+
+func TestWalkBibliographyInlineDestination(t *testing.T) {
+	source := "[Spec][cm], [spec](https://spec.commonmark.org) and ![cm].\n\n[cm]: https://spec.commonmark.org\n"
+
+	// The inline links to a defined destination are numbered
+	testComparableHelper(t, comparable{
+		source: source,
+		expected: "\n(Spec)[cm], (spec)[1] and (cm)[cm].\n\nLinks\n" +
+			"[cm] https://spec.commonmark.org\n[1] https://spec.commonmark.org\n",
+	}, testBibliographyTxtOptions(t))
+}
+
+func testBibliographyTxtOptions(t *testing.T) *Options {
+	options := testTxtOptions(t)
+
+	err := options.SetReferencePositionAndFileFormat(Bibliography, gophermap.FileFormatTxt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return options
+}
+
+func TestLinkDefinitions(t *testing.T) {
+	// The definition lines of the code blocks are not definitions
+	source := "```\n[a]: /code\n```\n\n[b]: /b\n\n> [A b]: /ab\n\n[a]: /a\n[b]: /b2\n"
+	w := NewWalkerWithOptions([]byte(source), testOptions)
+
+	expected := []string{"b", "A b", "a"}
+
+	if len(w.definitions) != len(expected) {
+		t.Fatalf("%d definitions have been found (expected: %d)", len(w.definitions), len(expected))
+	}
+
+	for i, definition := range w.definitions {
+		if definition.label != expected[i] {
+			t.Fatalf("'%s' is not the right label (expected: '%s')", definition.label, expected[i])
+		}
+	}
+}
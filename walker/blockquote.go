@@ -0,0 +1,134 @@
+package walker
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/theobori/lueur/internal/common"
+	"github.com/theobori/lueur/internal/wrap"
+)
+
+// Language of the quotation marks when neither the document nor the
+// options have one
+const DefaultQuoteLanguage = "en"
+
+type quotationMarks struct {
+	open  string
+	close string
+	// Marks of the quotes inside another quote
+	nestedOpen  string
+	nestedClose string
+}
+
+// The quotation marks per language subtag, the French ones are
+// separated from the text by no-break spaces
+var languageQuotationMarks = map[string]quotationMarks{
+	"de": {open: "„", close: "“", nestedOpen: "‚", nestedClose: "‘"},
+	"en": {open: "“", close: "”", nestedOpen: "‘", nestedClose: "’"},
+	"es": {open: "«", close: "»", nestedOpen: "“", nestedClose: "”"},
+	"fr": {open: "«\u00A0", close: "\u00A0»", nestedOpen: "“", nestedClose: "”"},
+	"it": {open: "«", close: "»", nestedOpen: "“", nestedClose: "”"},
+}
+
+// Reports whether there are quotation marks for the language tag
+func isQuoteLanguage(tag string) bool {
+	_, hasMarks := languageQuotationMarks[common.PrimaryLanguageSubtag(tag)]
+
+	return hasMarks
+}
+
+// The language subtags of the quotation marks, sorted
+func quoteLanguages() []string {
+	return slices.Sorted(maps.Keys(languageQuotationMarks))
+}
+
+// The quotation marks of the document language, the English ones are
+// used for the unknown languages of the front matters
+func (w *Walker) quotationMarks() quotationMarks {
+	language := w.language
+	if language == "" {
+		language = w.options.QuoteLanguage()
+	}
+
+	marks, hasMarks := languageQuotationMarks[common.PrimaryLanguageSubtag(language)]
+	if !hasMarks {
+		return languageQuotationMarks[DefaultQuoteLanguage]
+	}
+
+	return marks
+}
+
+// Prefix every line, the empty ones included so the quote is not
+// interrupted between its paragraphs
+func prefixQuoteLines(lines []string, prefix string) []string {
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + hanging(prefix) + line
+		}
+	}
+
+	return lines
+}
+
+// The quotation marks surround the quote, its wrapped lines are
+// aligned after the opening mark
+func (w *Walker) surroundQuoteLines(lines []string) []string {
+	marks := w.quotationMarks()
+	open, close := marks.open, marks.close
+
+	if w.ctx.BlockQuoteDepth.Value() > 1 {
+		open, close = marks.nestedOpen, marks.nestedClose
+	}
+
+	indent := strings.Repeat(" ", wrap.Width(open))
+
+	for i, line := range lines {
+		switch {
+		case i == 0:
+			lines[i] = open + hanging(indent) + line
+		case line != "":
+			lines[i] = indent + hanging(indent) + line
+		}
+	}
+
+	lines[len(lines)-1] += close
+
+	return lines
+}
+
+// Width of the prefixes of the walked block quotes
+func (w *Walker) quotePrefixWidth() int {
+	depth := w.ctx.BlockQuoteDepth.Value()
+	if depth == 0 {
+		return 0
+	}
+
+	switch w.options.BlockQuoteStyle {
+	case BlockQuoteStyleEmail, BlockQuoteStyleBar:
+		return 2 * depth
+	default:
+		marks := w.quotationMarks()
+
+		return wrap.Width(marks.open) + (depth-1)*wrap.Width(marks.nestedOpen)
+	}
+}
+
+// Quote the text of a block quote with the block quote style, the
+// nesting depth is the one of the context
+func (w *Walker) quoteText(s string) string {
+	lines := strings.Split(strings.Trim(s, "\n"), "\n")
+
+	switch w.options.BlockQuoteStyle {
+	case BlockQuoteStyleEmail:
+		lines = prefixQuoteLines(lines, "> ")
+	case BlockQuoteStyleBar:
+		lines = prefixQuoteLines(lines, "│ ")
+	default:
+		lines = w.surroundQuoteLines(lines)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,41 @@
+package walker
+
+import "fmt"
+
+type BlockQuoteStyle int
+
+const (
+	// Surrounded with the quotation marks of the document language
+	BlockQuoteStyleTypographic BlockQuoteStyle = iota
+	// Every line prefixed with "> " per nesting level
+	BlockQuoteStyleEmail
+	// Every line prefixed with a vertical bar per nesting level
+	BlockQuoteStyleBar
+)
+
+func NewBlockQuoteStyleFromString(s string) (BlockQuoteStyle, error) {
+	switch s {
+	case "typographic":
+		return BlockQuoteStyleTypographic, nil
+	case "email":
+		return BlockQuoteStyleEmail, nil
+	case "bar":
+		return BlockQuoteStyleBar, nil
+	default:
+		return BlockQuoteStyleTypographic, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+func (b BlockQuoteStyle) String() string {
+	switch b {
+	case BlockQuoteStyleTypographic:
+		return "typographic"
+	case BlockQuoteStyleEmail:
+		return "email"
+	case BlockQuoteStyleBar:
+		return "bar"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,186 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark/ast"
+	"golang.org/x/net/html"
+)
+
+func TestWalkBlockQuoteStyles(t *testing.T) {
+	source := `> Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+>
+> Second paragraph
+> > nested
+> > quote
+`
+
+	tests := []struct {
+		style BlockQuoteStyle
+		comp  comparable
+	}{
+		{
+			style: BlockQuoteStyleTypographic,
+			comp: comparable{
+				source: source,
+				expected: `
+“Lorem ipsum dolor sit amet, consectetur
+ adipiscing elit.
+
+ Second paragraph
+ ‘nested
+  quote’”
+`,
+			},
+		},
+		{
+			style: BlockQuoteStyleEmail,
+			comp: comparable{
+				source: source,
+				expected: `
+> Lorem ipsum dolor sit amet, consectetur
+> adipiscing elit.
+>
+> Second paragraph
+> > nested
+> > quote
+`,
+			},
+		},
+		{
+			style: BlockQuoteStyleBar,
+			comp: comparable{
+				source: source,
+				expected: `
+│ Lorem ipsum dolor sit amet, consectetur
+│ adipiscing elit.
+│
+│ Second paragraph
+│ │ nested
+│ │ quote
+`,
+			},
+		},
+		{
+			style: BlockQuoteStyleEmail,
+			comp: comparable{
+				source: "> a\n>\n> > b\n",
+				expected: `
+> a
+>
+> > b
+`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.BlockQuoteStyle = test.style
+
+		testComparableHelper(t, test.comp, options)
+	}
+}
+
+func TestWalkQuoteLanguage(t *testing.T) {
+	tests := []struct {
+		language string
+		comp     comparable
+	}{
+		{
+			language: "de",
+			comp: comparable{
+				source:   "> Hallo\n",
+				expected: "\n„Hallo“\n",
+			},
+		},
+		{
+			language: "de",
+			comp: comparable{
+				source:   "---\nlang: fr-FR\n---\n> Bonjour tout le monde, ceci est une citation assez longue.\n",
+				expected: "«\u00A0Bonjour tout le monde, ceci est une\n  citation assez longue.\u00A0»\n",
+			},
+		},
+		// The English marks are used for the unknown front matter languages
+		{
+			language: "de",
+			comp: comparable{
+				source:   "---\nlang: ja\n---\n> Hello\n",
+				expected: "“Hello”\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+
+		err := options.SetQuoteLanguage(test.language)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testComparableHelper(t, test.comp, options)
+	}
+}
+
+func TestSetQuoteLanguage(t *testing.T) {
+	options := testTxtOptions(t)
+
+	for _, language := range []string{"", "en", "de-AT", "fr_FR", "es", "it"} {
+		err := options.SetQuoteLanguage(language)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := options.SetQuoteLanguage("ja")
+	if err == nil {
+		t.Fatal("the quote language ja should be unsupported")
+	}
+}
+
+func TestBlockQuoteDepthOnError(t *testing.T) {
+	// The handlers ignore the errors of the quotes
+	localOptions := *testOptions
+	localOptions.SetNodeHandler(ast.KindBlockquote, func(_ *Walker, node ast.Node, next NodeWalkFunc) (string, error) {
+		_, err := next(node)
+		if err == nil {
+			t.Fatal("the quote should not be walked")
+		}
+
+		return "", nil
+	})
+	localOptions.SetHTMLHandler("blockquote", func(_ *Walker, node *html.Node, next HTMLNodeWalkFunc) (string, error) {
+		_, err := next(node)
+		if err == nil {
+			t.Fatal("the quote should not be walked")
+		}
+
+		return "", nil
+	})
+
+	w := NewWalkerWithOptions([]byte("> <img>\n"), &localOptions)
+
+	_, err := w.WalkFromRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.ctx.BlockQuoteDepth.Value() != 0 {
+		t.Fatalf("the block quote depth is %d after the quote", w.ctx.BlockQuoteDepth.Value())
+	}
+
+	w, err = NewHTMLDocumentWalkerWithOptions([]byte("<blockquote><img></blockquote>"), &localOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = w.WalkFromRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.ctx.BlockQuoteDepth.Value() != 0 {
+		t.Fatalf("the block quote depth is %d after the quote", w.ctx.BlockQuoteDepth.Value())
+	}
+}
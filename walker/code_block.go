@@ -0,0 +1,112 @@
+package walker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Indentation of the indented code blocks
+const codeBlockIndentation = "    "
+
+// Width of a tab in the framed code blocks
+const codeBlockTabWidth = 4
+
+// Replace the tabs with spaces up to the next tab stop
+func expandTabs(line string, tabWidth int) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+
+	builder := strings.Builder{}
+	column := 0
+
+	for _, r := range line {
+		if r == '\t' {
+			spaces := tabWidth - column%tabWidth
+			builder.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+			continue
+		}
+
+		builder.WriteRune(r)
+		column += textWidth(string(r))
+	}
+
+	return builder.String()
+}
+
+func numberCodeLines(lines []string) []string {
+	digits := len(strconv.Itoa(len(lines)))
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(fmt.Sprintf("%*d │ %s", digits, i+1, line), " ")
+	}
+
+	return lines
+}
+
+// The language is written in the top border
+func boxCodeLines(lines []string, caption string) []string {
+	width := maxTextWidth(lines)
+	if caption != "" {
+		width = max(width, textWidth(caption)+2)
+	}
+
+	top := ""
+	if caption != "" {
+		top = "─ " + caption + " "
+	}
+	top += strings.Repeat("─", width+2-textWidth(top))
+
+	boxLines := []string{"┌" + top + "┐"}
+	for _, line := range lines {
+		padding := strings.Repeat(" ", width-textWidth(line))
+		boxLines = append(boxLines, "│ "+line+padding+" │")
+	}
+	boxLines = append(boxLines, "└"+strings.Repeat("─", width+2)+"┘")
+
+	return boxLines
+}
+
+// Apply the code block options to the code lines, they are not wrapped
+// once they are numbered or framed
+func (w *Walker) presentCodeBlock(code string, language string) string {
+	caption := ""
+	if w.options.CodeBlockCaption {
+		caption = language
+	}
+
+	if caption == "" &&
+		!w.options.CodeBlockLineNumbers &&
+		w.options.CodeBlockFrame == CodeBlockFrameNone {
+		return code
+	}
+
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line, codeBlockTabWidth)
+	}
+
+	if w.options.CodeBlockLineNumbers {
+		lines = numberCodeLines(lines)
+	}
+
+	if w.options.CodeBlockFrame == CodeBlockFrameBox {
+		return noWrap(boxCodeLines(lines, caption))
+	}
+
+	if caption != "" {
+		lines = append([]string{"[" + caption + "]"}, lines...)
+	}
+
+	if w.options.CodeBlockFrame == CodeBlockFrameIndent {
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = codeBlockIndentation + line
+			}
+		}
+	}
+
+	return noWrap(lines)
+}
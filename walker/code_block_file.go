@@ -0,0 +1,99 @@
+package walker
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Value of a key=value attribute of a fenced code block info string,
+// such as filename="main.go" in ```go {filename="main.go"}
+func infoAttribute(info string, key string) string {
+	fields := strings.FieldsFunc(info, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '{' || r == '}' || r == ','
+	})
+
+	for _, field := range fields {
+		k, value, hasValue := strings.Cut(field, "=")
+		if hasValue && k == key {
+			return strings.Trim(value, "\"'")
+		}
+	}
+
+	return ""
+}
+
+// Reports whether a code block is written into its own text file, the
+// document must have a name to name the file after it
+func (w *Walker) isCodeBlockFile(code string) bool {
+	minLines := w.options.CodeBlockFileMinLines()
+
+	return w.name != "" &&
+		minLines > 0 &&
+		strings.Count(code, "\n")+1 >= minLines
+}
+
+// Reports whether a code block file of the document already has this path
+func (w *Walker) isCodeFilePath(filePath string) bool {
+	return slices.ContainsFunc(w.ctx.CodeFiles, func(page Page) bool {
+		return page.Path == filePath
+	})
+}
+
+// The file is named after the document and the filename attribute, or the
+// code block index. The index is added when the name is already taken.
+func (w *Walker) codeBlockFilePath(filename string) string {
+	filename = strings.TrimSuffix(path.Base(filename), ".txt")
+
+	if filename == "." || filename == "/" || filename == "" {
+		filename = fmt.Sprintf("code-%d", w.ctx.CodeBlockIndex)
+	}
+
+	name := w.name + "-" + filename
+	filePath := name + ".txt"
+
+	for i := w.ctx.CodeBlockIndex; w.isCodeFilePath(filePath); i++ {
+		filePath = fmt.Sprintf("%s-%d.txt", name, i)
+	}
+
+	return filePath
+}
+
+// Write a code block into a text file, it is replaced by its first lines.
+// The menu line linking the file is written after the walked block.
+func (w *Walker) codeBlockFile(code string, language string, filename string) (string, error) {
+	filePath := w.codeBlockFilePath(filename)
+	lines := strings.Split(code, "\n")
+
+	w.ctx.CodeFiles = append(w.ctx.CodeFiles, Page{
+		Path:       filePath,
+		Content:    code + "\n",
+		IsCodeFile: true,
+	})
+
+	title := filename
+	if title == "" {
+		title = fmt.Sprintf("Code block %d", w.ctx.CodeBlockIndex)
+	}
+	description := fmt.Sprintf("%s (%d lines)", title, len(lines))
+
+	line, err := w.referenceLine(description, filePath)
+	if err != nil {
+		return "", err
+	}
+	line.Description = w.renderer.ReferenceDescription(line.Description, filePath)
+
+	w.ctx.CodeFileLines = append(w.ctx.CodeFileLines, w.renderer.ReferenceLine(line))
+
+	previewLines := min(max(w.options.CodeBlockFilePreviewLines, 0), len(lines))
+	if previewLines == 0 {
+		return "", nil
+	}
+
+	preview := strings.Join(lines[:previewLines], "\n")
+	preview = w.presentCodeBlock(preview, language)
+
+	return w.renderer.CodeBlock(preview, language), nil
+}
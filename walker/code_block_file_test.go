@@ -0,0 +1,144 @@
+package walker
+
+import (
+	"testing"
+)
+
+func TestWalkCodeBlockFiles(t *testing.T) {
+	localOptions := *testOptions
+	localOptions.CodeBlockFilePreviewLines = 1
+
+	err := localOptions.SetCodeBlockFileMinLines(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := "```go {filename=\"main.go\"}\npackage main\n\nfunc main() {}\n```\n\n" +
+		"```\nshort\n```\n\n" +
+		"    a\n    b\n    c\n"
+
+	w := NewWalkerWithOptions([]byte(source), &localOptions)
+
+	pages, err := w.WalkDocument("docs/manual")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Page{
+		{
+			Path: "docs/manual.gophermap",
+			Content: testEmptyGophermapLineString + `ipackage main	/	localhost	70
+0main.go (3 lines)	/docs/manual-main.go.txt	localhost	70
+i	/	localhost	70
+ishort	/	localhost	70
+i	/	localhost	70
+ia	/	localhost	70
+0Code block 3 (3 lines)	/docs/manual-code-3.txt	localhost	70
+`,
+		},
+		{
+			Path:       "docs/manual-main.go.txt",
+			Content:    "package main\n\nfunc main() {}\n",
+			IsCodeFile: true,
+		},
+		{
+			Path:       "docs/manual-code-3.txt",
+			Content:    "a\nb\nc\n",
+			IsCodeFile: true,
+		},
+	}
+
+	if len(pages) != len(expected) {
+		t.Fatalf("%d pages have been walked (expected: %d)", len(pages), len(expected))
+	}
+
+	for i, page := range pages {
+		if page.Path != expected[i].Path || page.IsCodeFile != expected[i].IsCodeFile {
+			t.Fatalf("'%s' is not the right page path (expected: '%s')", page.Path, expected[i].Path)
+		}
+
+		if page.Content != expected[i].Content {
+			diff := testDmp.DiffMain(page.Content, expected[i].Content, false)
+
+			t.Fatal(testDmp.DiffPrettyText(diff))
+		}
+	}
+}
+
+func TestWalkCodeBlockFilePaths(t *testing.T) {
+	localOptions := testTxtOptions(t)
+
+	err := localOptions.SetCodeBlockFileMinLines(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := "```{filename=\"index\"}\na\n```\n\n" +
+		"- ```{filename=\"index.txt\"}\n  b\n  ```\n- c\n\n" +
+		"```{filename=\"code-4\"}\nd\n```\n\n" +
+		"```\ne\n```\n"
+
+	w := NewWalkerWithOptions([]byte(source), localOptions)
+
+	pages, err := w.WalkDocument("index")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"index.txt", "index-index.txt", "index-index-2.txt", "index-code-4.txt", "index-code-4-4.txt"}
+
+	if len(pages) != len(expected) {
+		t.Fatalf("%d pages have been walked (expected: %d)", len(pages), len(expected))
+	}
+
+	for i, page := range pages {
+		if page.Path != expected[i] {
+			t.Fatalf("'%s' is not the right page path (expected: '%s')", page.Path, expected[i])
+		}
+	}
+
+	// The link of a code block file follows the list of its item
+	expectedContent := "\nindex-index.txt\n\n- \n- c\nindex-index-2.txt\n\n" +
+		"index-code-4.txt\n\nindex-code-4-4.txt\n"
+
+	if pages[0].Content != expectedContent {
+		diff := testDmp.DiffMain(pages[0].Content, expectedContent, false)
+
+		t.Fatal(testDmp.DiffPrettyText(diff))
+	}
+}
+
+func TestWalkCodeBlockFilesWithoutName(t *testing.T) {
+	localOptions := testTxtOptions(t)
+
+	err := localOptions.SetCodeBlockFileMinLines(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The code blocks stay in the document written to a writer
+	testComparableHelper(t, comparable{
+		source:   "```\ncode\n```\n",
+		expected: "\ncode\n",
+	}, localOptions)
+}
+
+func TestInfoAttribute(t *testing.T) {
+	tests := []struct {
+		info     string
+		expected string
+	}{
+		{`go {filename="main.go"}`, "main.go"},
+		{"go filename=main.go", "main.go"},
+		{`go {.numbers, filename='a.go'}`, "a.go"},
+		{"go", ""},
+	}
+
+	for _, test := range tests {
+		value := infoAttribute(test.info, "filename")
+
+		if value != test.expected {
+			t.Fatalf("'%s' is not the right attribute value (expected: '%s')", value, test.expected)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package walker
+
+import "fmt"
+
+type CodeBlockFrame int
+
+const (
+	// The code lines as is
+	CodeBlockFrameNone CodeBlockFrame = iota
+	// Surrounded with box-drawing borders
+	CodeBlockFrameBox
+	// Indented with four spaces
+	CodeBlockFrameIndent
+)
+
+func NewCodeBlockFrameFromString(s string) (CodeBlockFrame, error) {
+	switch s {
+	case "none":
+		return CodeBlockFrameNone, nil
+	case "box":
+		return CodeBlockFrameBox, nil
+	case "indent":
+		return CodeBlockFrameIndent, nil
+	default:
+		return CodeBlockFrameNone, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+func (c CodeBlockFrame) String() string {
+	switch c {
+	case CodeBlockFrameNone:
+		return "none"
+	case CodeBlockFrameBox:
+		return "box"
+	case CodeBlockFrameIndent:
+		return "indent"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
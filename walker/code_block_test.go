@@ -0,0 +1,89 @@
+package walker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWalkCodeBlockPresentation(t *testing.T) {
+	source := "a\n\n```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```\n"
+
+	tests := []struct {
+		frame       CodeBlockFrame
+		caption     bool
+		lineNumbers bool
+		expected    string
+	}{
+		{
+			CodeBlockFrameNone, true, false,
+			"\na\n\n[go]\nfunc main() {\n    fmt.Println(\"hi\")\n}\n",
+		},
+		{
+			CodeBlockFrameNone, false, true,
+			"\na\n\n1 │ func main() {\n2 │     fmt.Println(\"hi\")\n3 │ }\n",
+		},
+		{
+			CodeBlockFrameIndent, true, false,
+			"\na\n\n    [go]\n    func main() {\n        fmt.Println(\"hi\")\n    }\n",
+		},
+		{
+			CodeBlockFrameBox, true, false,
+			"\na\n\n┌─ go ──────────────────┐\n" +
+				"│ func main() {         │\n" +
+				"│     fmt.Println(\"hi\") │\n" +
+				"│ }                     │\n" +
+				"└───────────────────────┘\n",
+		},
+		{
+			CodeBlockFrameBox, false, true,
+			"\na\n\n┌───────────────────────────┐\n" +
+				"│ 1 │ func main() {         │\n" +
+				"│ 2 │     fmt.Println(\"hi\") │\n" +
+				"│ 3 │ }                     │\n" +
+				"└───────────────────────────┘\n",
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.CodeBlockFrame = test.frame
+		options.CodeBlockCaption = test.caption
+		options.CodeBlockLineNumbers = test.lineNumbers
+
+		testComparableHelper(t, comparable{
+			source:   source,
+			expected: test.expected,
+		}, options)
+	}
+}
+
+func TestWalkCodeBlockLongLines(t *testing.T) {
+	options := testTxtOptions(t)
+	options.CodeBlockFrame = CodeBlockFrameBox
+
+	// The framed lines are never wrapped
+	testComparableHelper(t, comparable{
+		source: "    " + "aaaa bbbb cccc dddd eeee ffff gggg hhhh iiii jjjj\n",
+		expected: "\n┌" + strings.Repeat("─", 51) + "┐\n" +
+			"│ aaaa bbbb cccc dddd eeee ffff gggg hhhh iiii jjjj │\n" +
+			"└" + strings.Repeat("─", 51) + "┘\n",
+	}, options)
+}
+
+func TestNewCodeBlockFrameFromString(t *testing.T) {
+	for _, s := range []string{"none", "box", "indent"} {
+		frame, err := NewCodeBlockFrameFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if frame.String() != s {
+			t.Fatalf("expected %s, got %s", s, frame.String())
+		}
+	}
+
+	_, err := NewCodeBlockFrameFromString("frame")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -30,6 +30,28 @@ var (
 	testDmp *diffmatchpatch.DiffMatchPatch = diffmatchpatch.New()
 )
 
+// Options of a file format with the minimum word wrap limit
+func testFileFormatOptions(t *testing.T, fileFormat gophermap.FileFormat) *Options {
+	options, err := NewOptions(
+		WordWrapLimitMinimum,
+		AfterTraverse,
+		"localhost",
+		70,
+		false,
+		fileFormat,
+		"",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return options
+}
+
+func testTxtOptions(t *testing.T) *Options {
+	return testFileFormatOptions(t, gophermap.FileFormatTxt)
+}
+
 type comparable struct {
 	source   string
 	expected string
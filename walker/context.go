@@ -8,14 +8,44 @@ import (
 type Context struct {
 	Depth           *common.Counter
 	ReferencesQueue []gophermap.Line
-	Indentation     *common.Indentation
+	// Number of the queued references per destination
+	ReferenceNumbers map[string]int
+	// Amount of references written before the queued ones when the
+	// numbering does not restart per section
+	ReferenceOffset int
+	// Reference line of the cited link definitions per label
+	CitedDefinitions map[string]gophermap.Line
+	Indentation      *common.Indentation
+	// Depth of the HTML preformatted elements
+	Preformatted *common.Counter
+	// Depth of the block quotes
+	BlockQuoteDepth *common.Counter
+	// Width of the prefix of the walked list item, its indentation included
+	ListItemWidth int
+	// Every heading of the Markdown document, collected before the walk
+	Headings []tocEntry
+	// Index of the next walked heading
+	HeadingIndex int
+	// Page path of every heading anchor when the document is split
+	Anchors map[string]string
+	// Index of the last walked code block
+	CodeBlockIndex int
+	// Code blocks written into their own text file
+	CodeFiles []Page
+	// Menu lines linking the code block files of the walked block, they
+	// are written after it
+	CodeFileLines []string
 }
 
 func NewDefaultContext() *Context {
 	return &Context{
-		Depth:           common.NewDefaultCounter(),
-		ReferencesQueue: []gophermap.Line{},
-		Indentation:     common.NewDefaultIndentation(),
+		Depth:            common.NewDefaultCounter(),
+		ReferencesQueue:  []gophermap.Line{},
+		ReferenceNumbers: map[string]int{},
+		CitedDefinitions: map[string]gophermap.Line{},
+		Indentation:      common.NewDefaultIndentation(),
+		Preformatted:     common.NewDefaultCounter(),
+		BlockQuoteDepth:  common.NewDefaultCounter(),
 	}
 }
 
@@ -23,8 +53,18 @@ func (c *Context) Reset() {
 	c.ClearQueues()
 	c.Depth.Reset()
 	c.Indentation.Reset()
+	c.Preformatted.Reset()
+	c.BlockQuoteDepth.Reset()
+	c.ListItemWidth = 0
+	c.HeadingIndex = 0
+	c.ReferenceOffset = 0
+	c.CodeBlockIndex = 0
+	c.CodeFiles = nil
+	c.CodeFileLines = nil
 }
 
 func (c *Context) ClearQueues() {
 	c.ReferencesQueue = nil
+	c.ReferenceNumbers = map[string]int{}
+	c.CitedDefinitions = map[string]gophermap.Line{}
 }
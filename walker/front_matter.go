@@ -0,0 +1,66 @@
+package walker
+
+import (
+	"bytes"
+
+	meta "github.com/yuin/goldmark-meta"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"gopkg.in/yaml.v2"
+)
+
+// Reports whether a line is a front matter delimiter, made of dashes only
+func isFrontMatterDelimiter(line []byte) bool {
+	line = bytes.TrimSpace(line)
+
+	return len(line) > 0 && len(bytes.Trim(line, "-")) == 0
+}
+
+// Reports whether a source starts with a YAML front matter. The first line
+// must be "---", followed by a closing delimiter and a YAML mapping, the
+// other sources start with a thematic break.
+func hasFrontMatter(source []byte) bool {
+	first, rest, _ := bytes.Cut(source, []byte("\n"))
+	if string(bytes.TrimRight(first, " \t\r")) != "---" {
+		return false
+	}
+
+	var data bytes.Buffer
+
+	for len(rest) > 0 {
+		var line []byte
+		line, rest, _ = bytes.Cut(rest, []byte("\n"))
+
+		if isFrontMatterDelimiter(line) {
+			return yaml.Unmarshal(data.Bytes(), &map[string]any{}) == nil
+		}
+
+		data.Write(line)
+		data.WriteByte('\n')
+	}
+
+	return false
+}
+
+// Block parser of the goldmark-meta front matter, it is only opened for a
+// valid front matter. The extension itself writes the YAML errors into the
+// document.
+type frontMatterParser struct {
+	parser.BlockParser
+}
+
+// Priority of the front matter parser, the same as the goldmark-meta one
+const frontMatterParserPriority = 0
+
+func newFrontMatterParser() parser.BlockParser {
+	return frontMatterParser{BlockParser: meta.NewParser()}
+}
+
+func (p frontMatterParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	if !hasFrontMatter(reader.Source()) {
+		return nil, parser.NoChildren
+	}
+
+	return p.BlockParser.Open(parent, reader, pc)
+}
@@ -0,0 +1,49 @@
+package walker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHasFrontMatter(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected bool
+	}{
+		{"---\nlang: fr\n---\n\ntext\n", true},
+		{"---\n---\n", true},
+		{"---\n\nHello world\n", false},
+		{"---\nTitle\n---\n", false},
+		{"---\na: [\n---\n", false},
+		{"text\n---\n", false},
+	}
+
+	for _, test := range tests {
+		if hasFrontMatter([]byte(test.source)) != test.expected {
+			t.Fatalf("'%s' should have a front matter: %t", test.source, test.expected)
+		}
+	}
+}
+
+func TestWalkLeadingThematicBreak(t *testing.T) {
+	options := testTxtOptions(t)
+	options.ThematicBreakStyle = ThematicBreakStyleDashes
+
+	separator := strings.Repeat("-", WordWrapLimitMinimum)
+
+	testComparableMultipleHelper(t, []comparable{
+		{
+			source:   "---\n\nHello world\n",
+			expected: "\n" + separator + "\n\nHello world\n",
+		},
+		// A thematic break followed by a setext heading
+		{
+			source:   "---\nTitle\n---\n\ntext\n",
+			expected: "\n" + separator + "\nTitle\n\ntext\n",
+		},
+		{
+			source:   "---\nlang: fr\n---\n\ntext\n",
+			expected: "\ntext\n",
+		},
+	}, options)
+}
@@ -0,0 +1,43 @@
+package walker
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"golang.org/x/net/html"
+)
+
+// NodeWalkFunc renders a Markdown node
+type NodeWalkFunc func(node ast.Node) (string, error)
+
+// NodeHandler renders a Markdown node in place of the walker,
+// next is the default rendering of the node
+type NodeHandler func(w *Walker, node ast.Node, next NodeWalkFunc) (string, error)
+
+// HTMLNodeWalkFunc renders a HTML node
+type HTMLNodeWalkFunc func(node *html.Node) (string, error)
+
+// HTMLHandler renders a HTML element in place of the walker,
+// next is the default rendering of the element
+type HTMLHandler func(w *Walker, node *html.Node, next HTMLNodeWalkFunc) (string, error)
+
+// WalkChildren walks every child of the node and concatenates the results
+func (w *Walker) WalkChildren(node ast.Node) (string, error) {
+	return w.walkIteratorHelper(node)
+}
+
+// WalkHTMLChildren walks every child of the HTML node and concatenates the results
+func (w *Walker) WalkHTMLChildren(node *html.Node) (string, error) {
+	return w.walkHTMLIteratorHelper(node)
+}
+
+// Source returns the Markdown source, it is needed to read the node segments
+func (w *Walker) Source() []byte {
+	return w.source
+}
+
+func (w *Walker) Options() *Options {
+	return w.options
+}
+
+func (w *Walker) Renderer() Renderer {
+	return w.renderer
+}
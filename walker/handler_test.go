@@ -0,0 +1,166 @@
+package walker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/net/html"
+)
+
+var testKindWarning = ast.NewNodeKind("Warning")
+
+// A ':::warning' container
+type testWarning struct {
+	ast.BaseBlock
+}
+
+func (t *testWarning) Kind() ast.NodeKind {
+	return testKindWarning
+}
+
+func (t *testWarning) Dump(source []byte, level int) {
+	ast.DumpHelper(t, source, level, nil, nil)
+}
+
+type testWarningParser struct{}
+
+func (t *testWarningParser) Trigger() []byte {
+	return []byte{':'}
+}
+
+func (t *testWarningParser) Open(_ ast.Node, reader text.Reader, _ parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	if !bytes.HasPrefix(line, []byte(":::warning")) {
+		return nil, parser.NoChildren
+	}
+
+	reader.Advance(segment.Len() - 1)
+
+	return &testWarning{}, parser.HasChildren
+}
+
+func (t *testWarningParser) Continue(_ ast.Node, reader text.Reader, _ parser.Context) parser.State {
+	line, segment := reader.PeekLine()
+	if bytes.HasPrefix(line, []byte(":::")) {
+		reader.Advance(segment.Len() - 1)
+
+		return parser.Close
+	}
+
+	return parser.Continue | parser.HasChildren
+}
+
+func (t *testWarningParser) Close(_ ast.Node, _ text.Reader, _ parser.Context) {}
+
+func (t *testWarningParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (t *testWarningParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+type testWarningExtension struct{}
+
+func (t *testWarningExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithBlockParsers(
+		util.Prioritized(&testWarningParser{}, 100),
+	))
+}
+
+func TestNodeHandler(t *testing.T) {
+	test := comparable{
+		source: `# title
+
+:::warning
+Do **not** do that
+:::
+`,
+		expected: testEmptyGophermapLineString + `iTITLE	/	localhost	70
+i	/	localhost	70
+i/!\ Do not do that	/	localhost	70
+`,
+	}
+
+	localOptions := *testOptions
+	localOptions.Extensions = []goldmark.Extender{&testWarningExtension{}}
+	localOptions.SetNodeHandler(testKindWarning, func(w *Walker, node ast.Node, _ NodeWalkFunc) (string, error) {
+		s, err := w.WalkChildren(node)
+		if err != nil {
+			return "", err
+		}
+
+		return "\n/!\\ " + strings.TrimLeft(s, "\n"), nil
+	})
+	localOptions.SetNodeHandler(ast.KindHeading, func(_ *Walker, node ast.Node, next NodeWalkFunc) (string, error) {
+		s, err := next(node)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.ToUpper(s), nil
+	})
+
+	testComparableHelper(t, test, &localOptions)
+
+	// The handlers are not shared with the copied options
+	_, hasHandler := testOptions.NodeHandler(ast.KindHeading)
+	if hasHandler {
+		t.Fatal("the handlers must not be shared between options copies")
+	}
+}
+
+func TestDocumentNodeHandler(t *testing.T) {
+	test := comparable{
+		source: "a\n",
+		expected: testEmptyGophermapLineString + `ia	/	localhost	70
+iend	/	localhost	70
+`,
+	}
+
+	localOptions := *testOptions
+	localOptions.SetNodeHandler(ast.KindDocument, func(w *Walker, node ast.Node, next NodeWalkFunc) (string, error) {
+		s, err := next(node)
+		if err != nil {
+			return "", err
+		}
+
+		return s + w.Renderer().TextLine("end") + "\n", nil
+	})
+
+	testComparableHelper(t, test, &localOptions)
+}
+
+func TestHTMLHandler(t *testing.T) {
+	test := comparable{
+		source: `<div><span>a</span> <b>b</b></div>`,
+		expected: `i[a] B	/	localhost	70
+`,
+	}
+
+	localOptions := *testOptions
+	localOptions.SetHTMLHandler("span", func(w *Walker, node *html.Node, _ HTMLNodeWalkFunc) (string, error) {
+		s, err := w.WalkHTMLChildren(node)
+		if err != nil {
+			return "", err
+		}
+
+		return "[" + s + "]", nil
+	})
+	localOptions.SetHTMLHandler("b", func(_ *Walker, node *html.Node, next HTMLNodeWalkFunc) (string, error) {
+		s, err := next(node)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.ToUpper(s), nil
+	})
+
+	testComparableHelper(t, test, &localOptions)
+}
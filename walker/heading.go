@@ -0,0 +1,142 @@
+package walker
+
+import (
+	"strings"
+
+	"github.com/theobori/lueur/internal/figlet"
+	"github.com/theobori/lueur/internal/wrap"
+)
+
+// Width of a line without the wrapping markers
+func textWidth(s string) int {
+	return wrap.Width(strings.ReplaceAll(s, noWrapMarker, ""))
+}
+
+func maxTextWidth(lines []string) int {
+	width := 0
+	for _, line := range lines {
+		width = max(width, textWidth(line))
+	}
+
+	return width
+}
+
+// Mark every line so it is never wrapped
+func noWrap(lines []string) string {
+	for i, line := range lines {
+		if !strings.Contains(line, noWrapMarker) {
+			lines[i] = noWrapMarker + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (w *Walker) underlineHeading(s string, level int) string {
+	width := min(maxTextWidth(strings.Split(s, "\n")), w.options.WordWrapLimit())
+	if width == 0 {
+		return s
+	}
+
+	underline := "-"
+	if level == 1 {
+		underline = "="
+	}
+
+	return s + "\n" + noWrap([]string{strings.Repeat(underline, width)})
+}
+
+func (w *Walker) boxHeading(s string) string {
+	lines := strings.Split(s, "\n")
+	width := maxTextWidth(lines)
+
+	// The box would be broken by the wrapping
+	if width+4 > w.options.WordWrapLimit() {
+		return s
+	}
+
+	boxLines := []string{"┌" + strings.Repeat("─", width+2) + "┐"}
+	for _, line := range lines {
+		padding := strings.Repeat(" ", width-textWidth(line))
+		boxLines = append(boxLines, "│ "+line+padding+" │")
+	}
+	boxLines = append(boxLines, "└"+strings.Repeat("─", width+2)+"┘")
+
+	return noWrap(boxLines)
+}
+
+// The text lines are centered one by one, the decorated lines such as
+// a banner are centered as a block
+func (w *Walker) centerHeading(s string) string {
+	lines := strings.Split(s, "\n")
+	limit := w.options.WordWrapLimit()
+	blockWidth := 0
+
+	for _, line := range lines {
+		if strings.Contains(line, noWrapMarker) {
+			blockWidth = max(blockWidth, textWidth(line))
+		}
+	}
+
+	for i, line := range lines {
+		width := textWidth(line)
+		if strings.Contains(line, noWrapMarker) {
+			width = blockWidth
+		}
+
+		if width >= limit {
+			continue
+		}
+
+		line = strings.ReplaceAll(line, noWrapMarker, "")
+		lines[i] = noWrap([]string{strings.Repeat(" ", (limit-width)/2) + line})
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (w *Walker) figletHeading(s string) string {
+	text := strings.ReplaceAll(s, noWrapMarker, "")
+	text = strings.Join(strings.Fields(text), " ")
+
+	font := figlet.Standard()
+	if !font.Supports(text) {
+		return s
+	}
+
+	lines, err := font.Render(text)
+	if err != nil || maxTextWidth(lines) > w.options.WordWrapLimit() {
+		return s
+	}
+
+	return noWrap(lines)
+}
+
+// Apply the heading styles of the level in their order
+func (w *Walker) styleHeading(s string, level int) string {
+	for _, style := range w.options.HeadingStyles(level) {
+		switch style {
+		case HeadingStyleUppercase:
+			s = strings.ToUpper(s)
+		case HeadingStyleUnderline:
+			s = w.underlineHeading(s, level)
+		case HeadingStyleBox:
+			s = w.boxHeading(s)
+		case HeadingStyleCenter:
+			s = w.centerHeading(s)
+		case HeadingStyleFIGlet:
+			s = w.figletHeading(s)
+		}
+	}
+
+	return s
+}
+
+// Extra empty lines around a heading, they are marked so they are
+// not trimmed with the end of the block
+func (w *Walker) headingBlankLines(s string) string {
+	before := strings.Repeat(noWrapMarker+"\n", max(w.options.HeadingBlankLinesBefore, 0))
+	after := strings.Repeat("\n"+noWrapMarker, max(w.options.HeadingBlankLinesAfter, 0))
+
+	return before + s + after
+}
@@ -0,0 +1,77 @@
+package walker
+
+import (
+	"fmt"
+	"strings"
+)
+
+type HeadingStyle int
+
+const (
+	// The heading text as is
+	HeadingStylePlain HeadingStyle = iota
+	HeadingStyleUppercase
+	// Underlined with "=" for the level 1 and "-" for the others
+	HeadingStyleUnderline
+	// Surrounded with box-drawing characters
+	HeadingStyleBox
+	// Centered within the word wrap limit
+	HeadingStyleCenter
+	// ASCII banner written with the bundled FIGlet font
+	HeadingStyleFIGlet
+)
+
+func NewHeadingStyleFromString(s string) (HeadingStyle, error) {
+	switch s {
+	case "plain":
+		return HeadingStylePlain, nil
+	case "uppercase":
+		return HeadingStyleUppercase, nil
+	case "underline":
+		return HeadingStyleUnderline, nil
+	case "box":
+		return HeadingStyleBox, nil
+	case "center":
+		return HeadingStyleCenter, nil
+	case "figlet":
+		return HeadingStyleFIGlet, nil
+	default:
+		return HeadingStylePlain, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+// NewHeadingStylesFromString parses comma separated heading styles
+func NewHeadingStylesFromString(s string) ([]HeadingStyle, error) {
+	styles := []HeadingStyle{}
+
+	for name := range strings.SplitSeq(s, ",") {
+		style, err := NewHeadingStyleFromString(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		styles = append(styles, style)
+	}
+
+	return styles, nil
+}
+
+func (h HeadingStyle) String() string {
+	switch h {
+	case HeadingStylePlain:
+		return "plain"
+	case HeadingStyleUppercase:
+		return "uppercase"
+	case HeadingStyleUnderline:
+		return "underline"
+	case HeadingStyleBox:
+		return "box"
+	case HeadingStyleCenter:
+		return "center"
+	case HeadingStyleFIGlet:
+		return "figlet"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
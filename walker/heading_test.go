@@ -0,0 +1,217 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+func TestWalkHeadingStyles(t *testing.T) {
+	tests := []struct {
+		level  int
+		styles []HeadingStyle
+		comp   comparable
+	}{
+		{
+			level:  1,
+			styles: []HeadingStyle{HeadingStyleUppercase, HeadingStyleUnderline},
+			comp: comparable{
+				source:   "# Title\n",
+				expected: "\nTITLE\n=====\n",
+			},
+		},
+		{
+			level:  2,
+			styles: []HeadingStyle{HeadingStyleUnderline},
+			comp: comparable{
+				source:   "## Été\n",
+				expected: "\nÉté\n---\n",
+			},
+		},
+		{
+			level:  2,
+			styles: []HeadingStyle{HeadingStyleBox},
+			comp: comparable{
+				source:   "## Title\n",
+				expected: "\n┌───────┐\n│ Title │\n└───────┘\n",
+			},
+		},
+		{
+			level:  2,
+			styles: []HeadingStyle{HeadingStyleCenter},
+			comp: comparable{
+				source:   "## Title\n",
+				expected: "\n                    Title\n",
+			},
+		},
+		{
+			level:  1,
+			styles: []HeadingStyle{HeadingStyleFIGlet},
+			comp: comparable{
+				source: "# Hi\n",
+				expected: `
+ _   _  _
+| | | |(_)
+| |_| || |
+|  _  || |
+|_| |_||_|
+`,
+			},
+		},
+		{
+			level:  1,
+			styles: []HeadingStyle{HeadingStyleFIGlet},
+			comp: comparable{
+				source:   "# A banner wider than the limit\n",
+				expected: "\nA banner wider than the limit\n",
+			},
+		},
+		{
+			level:  1,
+			styles: []HeadingStyle{HeadingStyleBox},
+			comp: comparable{
+				source:   "## Not styled\n",
+				expected: "\nNot styled\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+
+		err := options.SetHeadingStyles(test.level, test.styles...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testComparableHelper(t, test.comp, options)
+	}
+}
+
+func TestWalkHeadingStylesGopher(t *testing.T) {
+	tests := []struct {
+		fileFormat gophermap.FileFormat
+		style      HeadingStyle
+		expected   string
+	}{
+		{
+			fileFormat: gophermap.FileFormatGophermap,
+			style:      HeadingStyleBox,
+			expected: "i\t/\tlocalhost\t70\n" +
+				"i┌─────┐\t/\tlocalhost\t70\n" +
+				"i│ Hi| │\t/\tlocalhost\t70\n" +
+				"i└─────┘\t/\tlocalhost\t70\n",
+		},
+		{
+			fileFormat: gophermap.FileFormatGophermap,
+			style:      HeadingStyleFIGlet,
+			expected: "i\t/\tlocalhost\t70\n" +
+				"i _   _  _  _\t/\tlocalhost\t70\n" +
+				"i| | | |(_)| |\t/\tlocalhost\t70\n" +
+				"i| |_| || || |\t/\tlocalhost\t70\n" +
+				"i|  _  || || |\t/\tlocalhost\t70\n" +
+				"i|_| |_||_|| |\t/\tlocalhost\t70\n" +
+				"i          |_|\t/\tlocalhost\t70\n",
+		},
+		{
+			fileFormat: gophermap.FileFormatGophermap,
+			style:      HeadingStyleCenter,
+			expected: "i\t/\tlocalhost\t70\n" +
+				"i                     Hi|\t/\tlocalhost\t70\n",
+		},
+		{
+			fileFormat: gophermap.FileFormatGophermap,
+			style:      HeadingStyleUnderline,
+			expected: "i\t/\tlocalhost\t70\n" +
+				"iHi|\t/\tlocalhost\t70\n" +
+				"i===\t/\tlocalhost\t70\n",
+		},
+		{
+			fileFormat: gophermap.FileFormatGPH,
+			style:      HeadingStyleBox,
+			expected: `[i||/|localhost|70]
+[i|┌─────┐|/|localhost|70]
+[i|│ Hi\| │|/|localhost|70]
+[i|└─────┘|/|localhost|70]
+`,
+		},
+		{
+			fileFormat: gophermap.FileFormatGPH,
+			style:      HeadingStyleFIGlet,
+			expected: `[i||/|localhost|70]
+[i| _   _  _  _|/|localhost|70]
+[i|\| \| \| \|(_)\| \||/|localhost|70]
+[i|\| \|_\| \|\| \|\| \||/|localhost|70]
+[i|\|  _  \|\| \|\| \||/|localhost|70]
+[i|\|_\| \|_\|\|_\|\| \||/|localhost|70]
+[i|          \|_\||/|localhost|70]
+`,
+		},
+		{
+			fileFormat: gophermap.FileFormatGPH,
+			style:      HeadingStyleCenter,
+			expected: `[i||/|localhost|70]
+[i|                     Hi\||/|localhost|70]
+`,
+		},
+		{
+			fileFormat: gophermap.FileFormatGPH,
+			style:      HeadingStyleUnderline,
+			expected: `[i||/|localhost|70]
+[i|Hi\||/|localhost|70]
+[i|===|/|localhost|70]
+`,
+		},
+	}
+
+	for _, test := range tests {
+		options := testFileFormatOptions(t, test.fileFormat)
+
+		err := options.SetHeadingStyles(1, test.style)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Every line is prefixed and escaped, the layout is not
+		testComparableHelper(t, comparable{
+			source:   "# Hi|\n",
+			expected: test.expected,
+		}, options)
+	}
+}
+
+func TestWalkHeadingBlankLines(t *testing.T) {
+	options := testTxtOptions(t)
+	options.HeadingBlankLinesBefore = 1
+	options.HeadingBlankLinesAfter = 2
+
+	test := comparable{
+		source:   "a\n\n## Title\n\nb\n",
+		expected: "\na\n\n\nTitle\n\n\n\nb\n",
+	}
+
+	testComparableHelper(t, test, options)
+}
+
+func TestSetHeadingStyles(t *testing.T) {
+	options := testTxtOptions(t)
+
+	err := options.SetHeadingStyles(0, HeadingStyleBox)
+	if err == nil {
+		t.Fatal("the heading level 0 should be invalid")
+	}
+
+	styles, err := NewHeadingStylesFromString("uppercase, box")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(styles) != 2 || styles[0] != HeadingStyleUppercase || styles[1] != HeadingStyleBox {
+		t.Fatalf("%v are not the right heading styles", styles)
+	}
+
+	_, err = NewHeadingStylesFromString("uppercase,shiny")
+	if err == nil {
+		t.Fatal("the heading style shiny should be invalid")
+	}
+}
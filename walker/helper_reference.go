@@ -2,8 +2,11 @@ package walker
 
 import (
 	"fmt"
+	"io"
 	"net/url"
 	"path/filepath"
+	"slices"
+	"strconv"
 
 	"github.com/theobori/lueur/gophermap"
 	"github.com/theobori/lueur/internal/common"
@@ -13,19 +16,31 @@ import (
 //
 // It's just handling the different edge cases with
 // specific walker reference position
-func (w *Walker) processReferenceLineEdgeCases(line *gophermap.Line, destination string) string {
+func (w *Walker) processReferenceLineEdgeCases(line *gophermap.Line, destination string, label string) string {
 	var inlineAnswer string
 
 	switch w.options.ReferencePosition() {
-	case AfterTraverse:
-		number := len(w.ctx.ReferencesQueue) + 1
-		inlineAnswer = fmt.Sprintf("(%s)[%d]", line.Description, number)
+	case AfterTraverse, Bibliography, AfterSection:
+		description := w.renderer.ReferenceDescription(line.Description, destination)
 
-		if w.options.FileFormat() == gophermap.FileFormatTxt {
-			line.Description = destination
+		// The defined references are labeled, the other ones are numbered
+		definition, isDefined := w.linkDefinition(label)
+		if isDefined {
+			label = definition.label
+			if definition.title != "" {
+				description = definition.title + ": " + description
+			}
+		} else {
+			number, isNumbered := w.ctx.ReferenceNumbers[destination]
+			if !isNumbered || !w.options.DeduplicateReferences {
+				number = w.ctx.ReferenceOffset + len(w.ctx.ReferencesQueue) + 1
+			}
+
+			label = strconv.Itoa(number)
 		}
 
-		line.Description = fmt.Sprintf("[%d] %s", number, line.Description)
+		inlineAnswer = fmt.Sprintf("(%s)[%s]", line.Description, label)
+		line.Description = fmt.Sprintf("[%s] %s", label, description)
 	case AfterBlocks:
 		inlineAnswer = line.Description
 	}
@@ -33,6 +48,55 @@ func (w *Walker) processReferenceLineEdgeCases(line *gophermap.Line, destination
 	return inlineAnswer
 }
 
+// Queue a reference line, with the deduplication the numbered references
+// of a destination are queued once and the identical lines are merged
+func (w *Walker) queueReference(line *gophermap.Line, destination string, label string) {
+	// A defined reference is cited once in a bibliography
+	definition, isDefined := w.linkDefinition(label)
+	if isDefined {
+		_, isCited := w.ctx.CitedDefinitions[definition.label]
+		if !isCited {
+			w.ctx.CitedDefinitions[definition.label] = *line
+		}
+
+		return
+	}
+
+	if w.options.DeduplicateReferences {
+		_, isNumbered := w.ctx.ReferenceNumbers[destination]
+		if isNumbered || slices.Contains(w.ctx.ReferencesQueue, *line) {
+			return
+		}
+	}
+
+	w.ctx.ReferencesQueue = append(w.ctx.ReferencesQueue, *line)
+
+	if w.options.ReferencePosition() != AfterBlocks {
+		w.ctx.ReferenceNumbers[destination] = w.ctx.ReferenceOffset + len(w.ctx.ReferencesQueue)
+	}
+}
+
+// Reports whether a heading of this level ends the section of the queued
+// references
+func (w *Walker) isSectionEnd(level int) bool {
+	return w.options.ReferencePosition() == AfterSection &&
+		level > 0 &&
+		level <= w.options.ReferenceSectionLevel() &&
+		len(w.ctx.ReferencesQueue) > 0
+}
+
+// Write the references of the section ending before a top-level heading
+// of this level, the other blocks have the level 0
+func (w *Walker) writeSectionReferences(writer io.Writer, level int) error {
+	if !w.isSectionEnd(level) {
+		return nil
+	}
+
+	_, err := io.WriteString(writer, w.referencesString())
+
+	return err
+}
+
 func (w *Walker) referenceLine(description string, destination string) (*gophermap.Line, error) {
 	line := gophermap.Line{
 		Description: description,
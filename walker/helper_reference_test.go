@@ -0,0 +1,137 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+const testDuplicatedReferencesSource = "See [a](https://a.org) and [b](https://a.org), [c](https://b.org) and [a](https://a.org).\n"
+
+func TestWalkDeduplicatedNumberedReferences(t *testing.T) {
+	options := testTxtOptions(t)
+	options.DeduplicateReferences = true
+
+	testComparableHelper(t, comparable{
+		source:   testDuplicatedReferencesSource,
+		expected: "\nSee (a)[1] and (b)[1], (c)[2] and (a)[1].\n[1] https://a.org\n[2] https://b.org\n",
+	}, options)
+
+	// Every reference has its own number by default
+	testComparableHelper(t, comparable{
+		source: testDuplicatedReferencesSource,
+		expected: "\nSee (a)[1] and (b)[2], (c)[3] and (a)[4].\n" +
+			"[1] https://a.org\n[2] https://a.org\n[3] https://b.org\n[4] https://a.org\n",
+	}, testTxtOptions(t))
+}
+
+func TestWalkDeduplicatedReferences(t *testing.T) {
+	localOptions := *testOptions
+	localOptions.DeduplicateReferences = true
+
+	// Only the identical lines of a block are merged
+	testComparableHelper(t, comparable{
+		source: testDuplicatedReferencesSource + "\nAgain [a](https://a.org).\n",
+		expected: testEmptyGophermapLineString +
+			"iSee a and b, c and a.\t/\tlocalhost\t70\n" +
+			"ha\tURL:https://a.org\ta.org\t443\n" +
+			"hb\tURL:https://a.org\ta.org\t443\n" +
+			"hc\tURL:https://b.org\tb.org\t443\n" +
+			testEmptyGophermapLineString +
+			"iAgain a.\t/\tlocalhost\t70\n" +
+			"ha\tURL:https://a.org\ta.org\t443\n",
+	}, &localOptions)
+}
+
+const testSectionReferencesSource = `# Article
+
+Intro [a](https://a.org).
+
+## One
+
+See [b](https://b.org).
+
+### Sub
+
+Also [c](https://c.org).
+
+## Two [d](https://d.org)
+`
+
+func testSectionOptions(t *testing.T) *Options {
+	options := testTxtOptions(t)
+
+	err := options.SetReferencePositionAndFileFormat(AfterSection, gophermap.FileFormatTxt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return options
+}
+
+func TestWalkSectionReferences(t *testing.T) {
+	options := testSectionOptions(t)
+
+	// The references of a heading are written in its section
+	testComparableHelper(t, comparable{
+		source: testSectionReferencesSource,
+		expected: "\nArticle\n\nIntro (a)[1].\n[1] https://a.org\n" +
+			"\nOne\n\nSee (b)[2].\n\nSub\n\nAlso (c)[3].\n[2] https://b.org\n[3] https://c.org\n" +
+			"\nTwo (d)[4]\n[4] https://d.org\n",
+	}, options)
+
+	options = testSectionOptions(t)
+	options.RestartReferenceNumbering = true
+
+	err := options.SetReferenceSectionLevel(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testComparableHelper(t, comparable{
+		source: testSectionReferencesSource,
+		expected: "\nArticle\n\nIntro (a)[1].\n[1] https://a.org\n" +
+			"\nOne\n\nSee (b)[1].\n[1] https://b.org\n\nSub\n\nAlso (c)[1].\n[1] https://c.org\n" +
+			"\nTwo (d)[1]\n[1] https://d.org\n",
+	}, options)
+
+	// The text of a section is never taken for its references
+	testComparableHelper(t, comparable{
+		source:   "a \uE006b [c](https://c.org)\n\n## d\n",
+		expected: "\na \uE006b (c)[1]\n[1] https://c.org\n\nd\n",
+	}, testSectionOptions(t))
+}
+
+func TestWalkHTMLSectionReferences(t *testing.T) {
+	testHTMLDocumentHelper(t, comparable{
+		source:   `<h1>T</h1><p>x <a href="https://a.org">a</a></p><h2>S</h2><p>y <a href="https://b.org">b</a></p>`,
+		expected: "T\n\nx (a)[1]\n[1] https://a.org\n\nS\n\ny (b)[2]\n[2] https://b.org\n",
+	}, testSectionOptions(t))
+}
+
+func TestSetReferenceSectionLevel(t *testing.T) {
+	localOptions := *testOptions
+
+	for _, level := range []int{0, HeadingLevelMaximum + 1} {
+		err := localOptions.SetReferenceSectionLevel(level)
+		if err == nil {
+			t.Fatalf("the reference section level %d should be invalid", level)
+		}
+	}
+}
+
+func TestTxtReferencePositions(t *testing.T) {
+	renderer := NewTxtRenderer(nil)
+
+	for _, referencePosition := range []OutputPosition{AfterTraverse, Bibliography, AfterSection} {
+		err := renderer.ValidateReferencePosition(referencePosition)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := renderer.ValidateReferencePosition(AfterBlocks)
+	if err == nil {
+		t.Fatal("the references of a text file must be numbered")
+	}
+}
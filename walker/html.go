@@ -2,9 +2,12 @@ package walker
 
 import (
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	lhtml "github.com/theobori/lueur/html"
+	"github.com/theobori/lueur/internal/wrap"
 	"golang.org/x/net/html"
 )
 
@@ -19,7 +22,11 @@ func (w *Walker) walkHTMLFromString(s string) (string, error) {
 }
 
 func (w *Walker) walkHTMLTextNode(node *html.Node) (string, error) {
-	return node.Data, nil
+	if !w.isHTMLDocument() || w.ctx.Preformatted.Value() > 0 {
+		return node.Data, nil
+	}
+
+	return collapseHTMLText(node), nil
 }
 
 func (w *Walker) walkHTMLP(node *html.Node) (string, error) {
@@ -28,49 +35,117 @@ func (w *Walker) walkHTMLP(node *html.Node) (string, error) {
 		return "", err
 	}
 
-	s = "\n" + strings.Trim(s, "\n") + "\n"
-
-	return s, nil
+	return w.htmlBlock(s), nil
 }
 
 func (w *Walker) walkHTMLB(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	return w.styleStrong(s), nil
+}
+
+func (w *Walker) walkHTMLEm(node *html.Node) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	return w.styleEmphasis(s), nil
+}
+
+// The code of the preformatted elements is not styled
+func (w *Walker) walkHTMLCode(node *html.Node) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	if w.ctx.Preformatted.Value() > 0 {
+		return s, nil
+	}
+
+	return w.styleCodeSpan(s), nil
 }
 
 func (w *Walker) walkHTMLH1(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	return w.walkHTMLHeading(node, 1)
 }
 
 func (w *Walker) walkHTMLH2(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	return w.walkHTMLHeading(node, 2)
 }
 
 func (w *Walker) walkHTMLH3(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	return w.walkHTMLHeading(node, 3)
 }
 
 func (w *Walker) walkHTMLH4(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	return w.walkHTMLHeading(node, 4)
 }
 
 func (w *Walker) walkHTMLH5(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	return w.walkHTMLHeading(node, 5)
 }
 
 func (w *Walker) walkHTMLH6(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	return w.walkHTMLHeading(node, 6)
 }
 
 func (w *Walker) walkHTMLHTML(node *html.Node) (string, error) {
 	return w.walkHTMLIteratorHelper(node)
 }
 
+// Only the title of a document is rendered, as the document heading
 func (w *Walker) walkHTMLHead(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	if !w.isHTMLDocument() {
+		return w.walkHTMLIteratorHelper(node)
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "title" {
+			return w.WalkHTML(c)
+		}
+	}
+
+	return "", nil
 }
 
+func (w *Walker) walkHTMLTitle(node *html.Node) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	s = w.headingText(strings.TrimSpace(s), 1)
+
+	return "\n" + s + "\n", nil
+}
+
+// The main content is extracted from a whole document
 func (w *Walker) walkHTMLBody(node *html.Node) (string, error) {
-	return w.walkHTMLIteratorHelper(node)
+	if !w.isHTMLDocument() {
+		return w.walkHTMLIteratorHelper(node)
+	}
+
+	content := htmlMainContent(node)
+	if len(content) == 0 {
+		return w.walkHTMLIteratorHelper(node)
+	}
+
+	builder := strings.Builder{}
+	for _, c := range content {
+		s, err := w.WalkHTML(c)
+		if err != nil {
+			return "", err
+		}
+
+		builder.WriteString(s)
+	}
+
+	return builder.String(), nil
 }
 
 func (w *Walker) walkHTMLDiv(node *html.Node) (string, error) {
@@ -79,20 +154,179 @@ func (w *Walker) walkHTMLDiv(node *html.Node) (string, error) {
 		return "", err
 	}
 
+	if w.isHTMLDocument() {
+		return w.htmlBlock(s), nil
+	}
+
 	s += "\n"
 
 	return s, nil
 }
 
-func (w *Walker) walkHTMLCenter(node *html.Node) (string, error) {
+// Elements only grouping other elements, such as <section> or <main>
+func (w *Walker) walkHTMLSectioning(node *html.Node) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	return w.htmlBlock(s), nil
+}
+
+// The page layout elements can be dropped
+func (w *Walker) walkHTMLLayout(node *html.Node) (string, error) {
+	if w.options.DropHTMLLayout {
+		return "", nil
+	}
+
+	return w.walkHTMLSectioning(node)
+}
+
+func (w *Walker) walkHTMLInline(node *html.Node) (string, error) {
+	return w.walkHTMLIteratorHelper(node)
+}
+
+func (w *Walker) walkHTMLA(node *html.Node) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	h := lhtml.MapFromAttributes(node.Attr)
+
+	href, hasHref := h["href"]
+	if !hasHref || href.Val == "" {
+		return s, nil
+	}
+
+	return w.walkHTMLReferenceHelper(strings.TrimSpace(s), w.pageDestination(href.Val))
+}
+
+func (w *Walker) walkHTMLBr(_ *html.Node) (string, error) {
+	return "\n", nil
+}
+
+func (w *Walker) walkHTMLHr(_ *html.Node) (string, error) {
+	return w.htmlBlock(w.thematicBreak()), nil
+}
+
+// The text of the preformatted elements is kept as is
+func (w *Walker) walkHTMLPreformatted(node *html.Node) (string, error) {
+	w.ctx.Preformatted.Add()
+	defer w.ctx.Preformatted.Remove()
+
+	return w.walkHTMLIteratorHelper(node)
+}
+
+func (w *Walker) walkHTMLPre(node *html.Node) (string, error) {
+	s, err := w.walkHTMLPreformatted(node)
+	if err != nil {
+		return "", err
+	}
+
+	s = strings.Trim(s, "\n")
+	w.ctx.CodeBlockIndex += 1
+
+	if w.isCodeBlockFile(s) {
+		s, err = w.codeBlockFile(s, "", "")
+		if err != nil {
+			return "", err
+		}
+	} else {
+		s = w.presentCodeBlock(s, "")
+		s = w.renderer.CodeBlock(s, "")
+	}
+
+	s = alignLines(s, leftAlignmentMarker)
+
+	return w.htmlBlock(s), nil
+}
+
+func (w *Walker) walkHTMLBlockQuote(node *html.Node) (string, error) {
+	w.ctx.BlockQuoteDepth.Add()
+	defer w.ctx.BlockQuoteDepth.Remove()
+
 	s, err := w.walkHTMLIteratorHelper(node)
 	if err != nil {
 		return "", err
 	}
 
-	// TODO: implement text centering ?
+	s = w.quoteText(strings.TrimSpace(s))
+
+	return w.htmlBlock(s), nil
+}
+
+func (w *Walker) walkHTMLList(node *html.Node) (string, error) {
+	items := []string{}
+	i := 1
 
-	s = "\n" + strings.Trim(s, "\n") + "\n"
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != "li" {
+			continue
+		}
+
+		prefix := "- "
+
+		if node.Data == "ol" {
+			prefix = strconv.Itoa(i) + ". "
+			i += 1
+		}
+
+		prefix = w.ctx.Indentation.IndentValue() + prefix
+
+		w.ctx.Indentation.Indent()
+		line, err := w.walkWithListItemPrefix(prefix, func() (string, error) {
+			return w.WalkHTML(c)
+		})
+		if err != nil {
+			return "", err
+		}
+		w.ctx.Indentation.UnIndent()
+
+		// The wrapped lines are aligned with the item text
+		indent := strings.Repeat(" ", wrap.Width(prefix))
+
+		items = append(items, prefix+hanging(indent)+strings.Trim(line, "\n "))
+	}
+
+	return w.htmlBlock(strings.Join(items, "\n")), nil
+}
+
+func (w *Walker) walkHTMLLi(node *html.Node) (string, error) {
+	return w.walkHTMLIteratorHelper(node)
+}
+
+// Every row is a line with its cells separated by pipes
+func (w *Walker) walkHTMLTr(node *html.Node) (string, error) {
+	cells := []string{}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		s, err := w.WalkHTML(c)
+		if err != nil {
+			return "", err
+		}
+
+		cells = append(cells, strings.TrimSpace(s))
+	}
+
+	return strings.Join(cells, " | ") + "\n", nil
+}
+
+func (w *Walker) walkHTMLSkip(_ *html.Node) (string, error) {
+	return "", nil
+}
+
+func (w *Walker) walkHTMLCenter(node *html.Node) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	s = "\n" + alignLines(strings.Trim(s, "\n"), centerAlignmentMarker) + "\n"
 
 	return s, nil
 }
@@ -124,6 +358,33 @@ func (w *Walker) walkHTMLStyle(_ *html.Node) (string, error) {
 }
 
 func (w *Walker) walkHTMLElementNode(node *html.Node) (string, error) {
+	var (
+		s   string
+		err error
+	)
+
+	handler, hasHandler := w.options.HTMLHandler(node.Data)
+	if hasHandler {
+		s, err = handler(w, node, w.walkHTMLTag)
+	} else {
+		s, err = w.walkHTMLTag(node)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// The deprecated align attribute of the block elements
+	h := lhtml.MapFromAttributes(node.Attr)
+	align, hasAlign := h["align"]
+	if hasAlign && isHTMLBlockElement(node) && strings.EqualFold(align.Val, "center") {
+		s = alignLines(s, centerAlignmentMarker)
+	}
+
+	return s, nil
+}
+
+func (w *Walker) walkHTMLTag(node *html.Node) (string, error) {
 	switch node.Data {
 	case "html":
 		return w.walkHTMLHTML(node)
@@ -143,8 +404,42 @@ func (w *Walker) walkHTMLElementNode(node *html.Node) (string, error) {
 		return w.walkHTMLH5(node)
 	case "h6":
 		return w.walkHTMLH6(node)
-	case "b":
+	case "title":
+		return w.walkHTMLTitle(node)
+	case "b", "strong":
 		return w.walkHTMLB(node)
+	case "i", "em":
+		return w.walkHTMLEm(node)
+	case "code", "kbd", "samp":
+		return w.walkHTMLCode(node)
+	case "a":
+		return w.walkHTMLA(node)
+	case "u", "s", "small", "span", "abbr", "cite", "q", "sub", "sup",
+		"mark", "time", "label", "var", "del", "ins", "dfn", "bdi", "bdo",
+		"data", "picture":
+		return w.walkHTMLInline(node)
+	case "main", "article", "section", "figure", "figcaption", "address",
+		"details", "summary", "dl", "dt", "dd", "table", "thead", "tbody",
+		"tfoot", "caption":
+		return w.walkHTMLSectioning(node)
+	case "nav", "header", "footer", "aside":
+		return w.walkHTMLLayout(node)
+	case "ul", "ol":
+		return w.walkHTMLList(node)
+	case "li":
+		return w.walkHTMLLi(node)
+	case "tr":
+		return w.walkHTMLTr(node)
+	case "td", "th":
+		return w.walkHTMLInline(node)
+	case "br":
+		return w.walkHTMLBr(node)
+	case "hr":
+		return w.walkHTMLHr(node)
+	case "pre":
+		return w.walkHTMLPre(node)
+	case "blockquote":
+		return w.walkHTMLBlockQuote(node)
 	case "p":
 		return w.walkHTMLP(node)
 	case "div":
@@ -155,6 +450,10 @@ func (w *Walker) walkHTMLElementNode(node *html.Node) (string, error) {
 		return w.walkHTMLImg(node)
 	case "style":
 		return w.walkHTMLStyle(node)
+	case "script", "noscript", "template", "link", "meta", "base", "svg",
+		"canvas", "form", "input", "button", "select", "textarea", "iframe",
+		"video", "audio", "source", "track", "object", "embed":
+		return w.walkHTMLSkip(node)
 	default:
 		return "", fmt.Errorf("unsupported HTML node type: %s", node.Data)
 	}
@@ -181,3 +480,60 @@ func (w *Walker) WalkHTML(node *html.Node) (string, error) {
 
 	return s, nil
 }
+
+// The top-level blocks of the HTML document are formatted and written one
+// by one like the Markdown blocks, the inline nodes between them are
+// grouped into a single block
+func (w *Walker) walkHTMLDocumentTo(writer io.Writer) error {
+	isWritten := false
+
+	for _, group := range w.htmlDocumentBlocks() {
+		err := w.writeSectionReferences(writer, htmlHeadingLevel(group[0]))
+		if err != nil {
+			return err
+		}
+
+		builder := strings.Builder{}
+
+		for _, node := range group {
+			s, err := w.WalkHTML(node)
+			if err != nil {
+				return err
+			}
+
+			builder.WriteString(s)
+		}
+
+		s := builder.String()
+
+		// The blocks of a document are separated by a blank line
+		if w.isHTMLDocument() {
+			s = cleanHTMLDocumentText(s)
+			if isWritten && s != "" {
+				s = "\n" + s
+			}
+		}
+
+		if s == "" && !w.isReferencesOutputable() {
+			continue
+		}
+
+		s, err = w.formatDepthOneText(s)
+		if err != nil {
+			return err
+		}
+
+		if w.isReferencesOutputable() {
+			s += w.referencesString()
+		}
+
+		_, err = io.WriteString(writer, s)
+		if err != nil {
+			return err
+		}
+
+		isWritten = true
+	}
+
+	return nil
+}
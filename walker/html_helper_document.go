@@ -0,0 +1,172 @@
+package walker
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	htmlWhitespaceRegexp = regexp.MustCompile(`[ \t\r\n\f]+`)
+	// More than one blank line in a row
+	htmlBlankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+	// Trailing whitespaces of every line
+	htmlTrailingSpacesRegexp = regexp.MustCompile(`(?m)[ \t]+$`)
+)
+
+// Elements starting a new line, the whitespaces around them are not rendered
+var htmlBlockElements = map[string]bool{
+	"html": true, "head": true, "title": true, "body": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"p": true, "div": true, "center": true, "br": true, "hr": true,
+	"pre": true, "blockquote": true, "ul": true, "ol": true, "li": true,
+	"main": true, "article": true, "section": true, "nav": true,
+	"header": true, "footer": true, "aside": true, "address": true,
+	"figure": true, "figcaption": true, "details": true, "summary": true,
+	"dl": true, "dt": true, "dd": true, "table": true, "thead": true,
+	"tbody": true, "tfoot": true, "caption": true, "tr": true, "td": true,
+	"th": true,
+}
+
+// The walker has been created from a whole HTML document and not from
+// fragments
+func (w *Walker) isHTMLDocument() bool {
+	return w.htmlDocument
+}
+
+func isHTMLBlockElement(node *html.Node) bool {
+	return node != nil && node.Type == html.ElementNode && htmlBlockElements[node.Data]
+}
+
+// Collapse the whitespaces like a browser would do, they are removed at
+// the boundaries of the block elements
+func collapseHTMLText(node *html.Node) string {
+	s := htmlWhitespaceRegexp.ReplaceAllString(node.Data, " ")
+
+	if node.PrevSibling == nil && isHTMLBlockElement(node.Parent) ||
+		isHTMLBlockElement(node.PrevSibling) {
+		s = strings.TrimLeft(s, " ")
+	}
+
+	if node.NextSibling == nil && isHTMLBlockElement(node.Parent) ||
+		isHTMLBlockElement(node.NextSibling) {
+		s = strings.TrimRight(s, " ")
+	}
+
+	return s
+}
+
+// Remove the blank lines and the whitespaces left by the document layout
+func cleanHTMLDocumentText(s string) string {
+	s = htmlTrailingSpacesRegexp.ReplaceAllString(s, "")
+	s = htmlBlankLinesRegexp.ReplaceAllString(s, "\n\n")
+
+	return strings.Trim(s, "\n")
+}
+
+func (w *Walker) htmlBlock(s string) string {
+	return "\n" + strings.Trim(s, "\n") + "\n"
+}
+
+func (w *Walker) walkHTMLHeading(node *html.Node, level int) (string, error) {
+	s, err := w.walkHTMLIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	// The fragments embedded into Markdown are kept inline
+	if !w.isHTMLDocument() {
+		return s, nil
+	}
+
+	s = w.headingText(strings.TrimSpace(s), level)
+
+	return w.htmlBlock(s), nil
+}
+
+// The top-level blocks of the document, its head and the children of its
+// body or of its main content, a handler of the root elements renders the whole document
+// as a single block
+func (w *Walker) htmlDocumentBlocks() [][]*html.Node {
+	_, hasHTMLHandler := w.options.HTMLHandler("html")
+	_, hasBodyHandler := w.options.HTMLHandler("body")
+	if hasHTMLHandler || hasBodyHandler {
+		return [][]*html.Node{{w.htmlNode}}
+	}
+
+	blocks := [][]*html.Node{}
+	for _, head := range findHTMLElements(w.htmlNode, "head") {
+		blocks = append(blocks, []*html.Node{head})
+	}
+
+	containers := findHTMLElements(w.htmlNode, "body")
+	if len(containers) == 0 {
+		return blocks
+	}
+
+	// The main content is extracted from a whole document
+	if w.isHTMLDocument() {
+		content := htmlMainContent(containers[0])
+		if len(content) > 0 {
+			containers = content
+		}
+	}
+
+	for _, container := range containers {
+		// The consecutive inline nodes are grouped
+		isInline := false
+
+		for c := container.FirstChild; c != nil; c = c.NextSibling {
+			if isInline && !isHTMLBlockElement(c) {
+				blocks[len(blocks)-1] = append(blocks[len(blocks)-1], c)
+				continue
+			}
+
+			blocks = append(blocks, []*html.Node{c})
+			isInline = !isHTMLBlockElement(c)
+		}
+	}
+
+	return blocks
+}
+
+// The main content of a body, its first main element or else its articles
+func htmlMainContent(body *html.Node) []*html.Node {
+	main := findHTMLElements(body, "main")
+	if len(main) > 0 {
+		return main[:1]
+	}
+
+	return findHTMLElements(body, "article")
+}
+
+// Level of a heading element, 0 for the other nodes
+func htmlHeadingLevel(node *html.Node) int {
+	if node.Type != html.ElementNode || len(node.Data) != 2 || node.Data[0] != 'h' {
+		return 0
+	}
+
+	level := int(node.Data[1] - '0')
+	if level < 1 || level > HeadingLevelMaximum {
+		return 0
+	}
+
+	return level
+}
+
+// Every element named name in the subtree, without the nested ones
+func findHTMLElements(node *html.Node, name string) []*html.Node {
+	nodes := []*html.Node{}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == name {
+			nodes = append(nodes, c)
+			continue
+		}
+
+		nodes = append(nodes, findHTMLElements(c, name)...)
+	}
+
+	return nodes
+}
@@ -10,9 +10,9 @@ func (w *Walker) walkHTMLReferenceHelper(alt string, destination string) (string
 		return "", err
 	}
 
-	inlineText := w.processReferenceLineEdgeCases(line, destination)
+	inlineText := w.processReferenceLineEdgeCases(line, destination, "")
 
-	w.ctx.ReferencesQueue = append(w.ctx.ReferencesQueue, *line)
+	w.queueReference(line, destination, "")
 
 	return inlineText, nil
 }
@@ -0,0 +1,93 @@
+package walker
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"golang.org/x/net/html"
+)
+
+// AST transformer turning the inline anchors embedded into Markdown into
+// links, the parser splits their tags into distinct raw HTML nodes
+type htmlInlineLinkTransformer struct{}
+
+// Priority of the transformer, it has no dependency on the other ones
+const htmlInlineLinkTransformerPriority = 100
+
+// Attributes of a raw HTML anchor opening tag
+func htmlAnchorAttributes(node ast.Node, source []byte) (map[string]string, bool) {
+	rawHTML, isRawHTML := node.(*ast.RawHTML)
+	if !isRawHTML {
+		return nil, false
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(string(rawHTML.Segments.Value(source))))
+	if tokenizer.Next() != html.StartTagToken {
+		return nil, false
+	}
+
+	token := tokenizer.Token()
+	if token.Data != "a" {
+		return nil, false
+	}
+
+	attributes := map[string]string{}
+	for _, attribute := range token.Attr {
+		attributes[attribute.Key] = attribute.Val
+	}
+
+	return attributes, true
+}
+
+func isHTMLAnchorEnd(node ast.Node, source []byte) bool {
+	rawHTML, isRawHTML := node.(*ast.RawHTML)
+	if !isRawHTML {
+		return false
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(string(rawHTML.Segments.Value(source))))
+
+	return tokenizer.Next() == html.EndTagToken && tokenizer.Token().Data == "a"
+}
+
+// The nodes between an anchor opening tag and its closing sibling become
+// the children of a link
+func (t htmlInlineLinkTransformer) Transform(node *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		attributes, isAnchor := htmlAnchorAttributes(n, source)
+		if !isAnchor || attributes["href"] == "" {
+			return ast.WalkContinue, nil
+		}
+
+		end := n.NextSibling()
+		for end != nil && !isHTMLAnchorEnd(end, source) {
+			end = end.NextSibling()
+		}
+
+		if end == nil {
+			return ast.WalkContinue, nil
+		}
+
+		link := ast.NewLink()
+		link.Destination = []byte(attributes["href"])
+		link.Title = []byte(attributes["title"])
+
+		for c := n.NextSibling(); c != end; c = n.NextSibling() {
+			link.AppendChild(link, c)
+		}
+
+		parent := n.Parent()
+		parent.RemoveChild(parent, end)
+		parent.ReplaceChild(parent, n, link)
+
+		return ast.WalkSkipChildren, nil
+	})
+}
@@ -1,6 +1,10 @@
 package walker
 
-import "testing"
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
 
 func TestWalkHTMLH1(t *testing.T) {
 	test := comparable{
@@ -166,3 +170,216 @@ hhttps://google.fr	URL:https://google.fr	google.fr	443
 
 	testComparableMultipleHelper(t, tests, testOptions)
 }
+
+func TestWalkHTMLEmbedded(t *testing.T) {
+	// The elements of the documents are rendered in Markdown too
+	testComparableMultipleHelper(t, []comparable{
+		{
+			source:   "<p>a<br>b</p>\n",
+			expected: "\na\nb\n",
+		},
+		{
+			source:   "<ul><li>one</li><li>two</li></ul>\n",
+			expected: "\n- one\n- two\n",
+		},
+		{
+			source:   "<pre>a  b\n c</pre>\n",
+			expected: "\na  b\n c\n",
+		},
+		{
+			source:   "a <a href=\"https://a.com\">link</a> b\n",
+			expected: "\na (link)[1] b\n[1] https://a.com\n",
+		},
+	}, testTxtOptions(t))
+}
+
+func TestHTMLWalker(t *testing.T) {
+	source := `<html><body><p>a <b>b</b></p><div>c <img src="/d.png" alt="d"></div></body></html>`
+	expected := `i	/	localhost	70
+ia b	/	localhost	70
+ic d	/	localhost	70
+Id	/d.png	localhost	70
+`
+
+	w, err := NewHTMLWalkerWithOptions([]byte(source), testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := w.WalkFromRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s != expected {
+		diff := testDmp.DiffMain(s, expected, false)
+
+		t.Fatal(testDmp.DiffPrettyText(diff))
+	}
+}
+
+func testHTMLDocumentHelper(t *testing.T, comp comparable, options *Options) {
+	w, err := NewHTMLDocumentWalkerWithOptions([]byte(comp.source), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := w.WalkFromRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s != comp.expected {
+		diff := testDmp.DiffMain(s, comp.expected, false)
+
+		t.Fatal(testDmp.DiffPrettyText(diff))
+	}
+}
+
+func TestHTMLWalkerDocument(t *testing.T) {
+	localOptions := *testOptions
+	localOptions.WriteFancyHeader = true
+
+	tests := []comparable{
+		{
+			source: `<!DOCTYPE html>
+<html>
+  <head>
+    <title> My   page </title>
+    <meta charset="utf-8">
+    <script>alert(1)</script>
+  </head>
+  <body>
+    <nav><a href="/">Home</a></nav>
+    <main>
+      <h2>Section</h2>
+      <p>
+        Some   <em>text</em>
+        with a <a href="https://a.com">link</a>.
+      </p>
+      <ul>
+        <li>one</li>
+        <li>two
+          <ol><li>three</li></ol>
+        </li>
+      </ul>
+      <pre>a  b
+  c</pre>
+    </main>
+    <footer>Footer</footer>
+  </body>
+</html>`,
+			expected: `i# My page	/	localhost	70
+i	/	localhost	70
+i## Section	/	localhost	70
+i	/	localhost	70
+iSome text with a link.	/	localhost	70
+hlink	URL:https://a.com	a.com	443
+i	/	localhost	70
+i- one	/	localhost	70
+i- two	/	localhost	70
+i  1. three	/	localhost	70
+i	/	localhost	70
+ia  b	/	localhost	70
+i  c	/	localhost	70
+`,
+		},
+		{
+			source: `<html><body>
+<header>Header</header>
+<article><p>First</p></article>
+<aside>Aside</aside>
+<article><p>Second</p><blockquote>Quote</blockquote></article>
+</body></html>`,
+			expected: `iFirst	/	localhost	70
+i	/	localhost	70
+iSecond	/	localhost	70
+i	/	localhost	70
+i“Quote”	/	localhost	70
+`,
+		},
+	}
+
+	for _, test := range tests {
+		testHTMLDocumentHelper(t, test, &localOptions)
+	}
+}
+
+func TestHTMLWalkerDocumentBlocks(t *testing.T) {
+	testHTMLDocumentHelper(t, comparable{
+		source: `<html><body>
+Some <b>inline</b> <a href="/a">text</a>
+<p>A <a href="/b">paragraph</a></p>
+Tail
+</body></html>`,
+		expected: `iSome inline text	/	localhost	70
+1text	/a	localhost	70
+i	/	localhost	70
+iA paragraph	/	localhost	70
+1paragraph	/b	localhost	70
+i	/	localhost	70
+iTail	/	localhost	70
+`,
+	}, testOptions)
+}
+
+func TestHTMLWalkerDocumentLayout(t *testing.T) {
+	source := `<html><body>
+<header>Header</header>
+<nav>Nav</nav>
+<p>Content</p>
+<aside>Aside</aside>
+<footer>Footer</footer>
+</body></html>`
+
+	localOptions := *testOptions
+
+	testHTMLDocumentHelper(t, comparable{
+		source: source,
+		expected: `iHeader	/	localhost	70
+i	/	localhost	70
+iNav	/	localhost	70
+i	/	localhost	70
+iContent	/	localhost	70
+i	/	localhost	70
+iAside	/	localhost	70
+i	/	localhost	70
+iFooter	/	localhost	70
+`,
+	}, &localOptions)
+
+	localOptions.DropHTMLLayout = true
+
+	testHTMLDocumentHelper(t, comparable{
+		source:   source,
+		expected: "iContent\t/\tlocalhost\t70\n",
+	}, &localOptions)
+}
+
+func TestPreformattedOnError(t *testing.T) {
+	localOptions := *testOptions
+	localOptions.SetHTMLHandler("pre", func(_ *Walker, node *html.Node, next HTMLNodeWalkFunc) (string, error) {
+		_, err := next(node)
+		if err == nil {
+			t.Fatal("the preformatted element should not be walked")
+		}
+
+		return "", nil
+	})
+
+	w, err := NewHTMLDocumentWalkerWithOptions([]byte("<pre><img></pre><p>a  b</p>"), &localOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := w.WalkFromRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The whitespaces after the preformatted element are collapsed
+	expected := "ia b\t/\tlocalhost\t70\n"
+	if s != expected {
+		t.Fatalf("%q is not the right output (expected: %q)", s, expected)
+	}
+}
@@ -0,0 +1,111 @@
+package walker
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/theobori/lueur/internal/hyphen"
+	"github.com/theobori/lueur/internal/wrap"
+	"github.com/yuin/goldmark/ast"
+)
+
+// Language of the hyphenation when neither the document nor the
+// options have one
+const DefaultHyphenationLanguage = "en"
+
+// The hyphenator of the document language, nil if the hyphenation is
+// disabled or if the language is not supported
+func (w *Walker) hyphenator() *hyphen.Hyphenator {
+	if !w.options.Hyphenation {
+		return nil
+	}
+
+	language := w.language
+	if language == "" {
+		language = w.options.HyphenationLanguage()
+	}
+
+	if language == "" {
+		language = DefaultHyphenationLanguage
+	}
+
+	h, err := hyphen.ForLanguage(language)
+	if err != nil {
+		return nil
+	}
+
+	return h
+}
+
+// The text of the headings, the links and the code spans is never hyphenated
+func isHyphenatable(node ast.Node) bool {
+	for n := node.Parent(); n != nil; n = n.Parent() {
+		switch n.(type) {
+		case *ast.Heading, *ast.Link, *ast.Image, *ast.CodeSpan:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+// The words made of letters, hyphens and apostrophes, without counting
+// their surrounding punctuation. The URLs, the paths and the numbers
+// are not words.
+func isHyphenatableWord(s string) bool {
+	core := strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+
+	if core == "" {
+		return false
+	}
+
+	for _, r := range core {
+		if !isWordRune(r) && r != '-' && r != '\'' && r != '’' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Insert soft hyphens at the hyphenation points of the words
+func hyphenateText(s string, h *hyphen.Hyphenator) string {
+	words := strings.Split(s, " ")
+
+	for i, word := range words {
+		if !isHyphenatableWord(word) {
+			continue
+		}
+
+		builder := strings.Builder{}
+		letters := []rune{}
+
+		flush := func() {
+			if len(letters) > 0 {
+				builder.WriteString(strings.Join(h.Hyphenate(string(letters)), wrap.SoftHyphen))
+				letters = letters[:0]
+			}
+		}
+
+		for _, r := range word {
+			if isWordRune(r) {
+				letters = append(letters, r)
+				continue
+			}
+
+			flush()
+			builder.WriteRune(r)
+		}
+		flush()
+
+		words[i] = builder.String()
+	}
+
+	return strings.Join(words, " ")
+}
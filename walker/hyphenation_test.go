@@ -0,0 +1,93 @@
+package walker
+
+import "testing"
+
+func TestWalkHyphenation(t *testing.T) {
+	tests := []struct {
+		language string
+		comp     comparable
+	}{
+		{
+			comp: comparable{
+				source: "Characterization of incomprehensibilities `incomprehensibilities` and internationalization https://example.com/internationalization.\n",
+				expected: `
+Characterization of incomprehensibilities
+incomprehensibilities and internationaliza-
+tion https://example.com/
+internationalization.
+`,
+			},
+		},
+		{
+			comp: comparable{
+				source: `---
+lang: fr
+---
+Les ordinateurs personnels sont incontestablement extraordinaires.
+`,
+				expected: `Les ordinateurs personnels sont incontesta-
+blement extraordinaires.
+`,
+			},
+		},
+		{
+			language: "de",
+			comp: comparable{
+				source: "Die Kapitänsmütze ist lang und die Silbentrennung hilft.\n",
+				expected: `
+Die Kapitänsmütze ist lang und die Silben-
+trennung hilft.
+`,
+			},
+		},
+		{
+			comp: comparable{
+				source: `---
+lang: ja
+---
+Characterization of incomprehensibilities internationalization.
+`,
+				expected: `Characterization of incomprehensibilities
+internationalization.
+`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.Hyphenation = true
+
+		err := options.SetHyphenationLanguage(test.language)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testComparableHelper(t, test.comp, options)
+	}
+
+	// The hyphenation is disabled by default
+	testComparableHelper(t, comparable{
+		source: "Characterization of incomprehensibilities internationalization.\n",
+		expected: `
+Characterization of incomprehensibilities
+internationalization.
+`,
+	}, testTxtOptions(t))
+}
+
+func TestSetHyphenationLanguage(t *testing.T) {
+	options := testTxtOptions(t)
+
+	for _, language := range []string{"", "en", "en-US", "de_DE", "fr", "es"} {
+		err := options.SetHyphenationLanguage(language)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := options.SetHyphenationLanguage("ja")
+	if err == nil {
+		t.Fatal("the hyphenation language ja should be unsupported")
+	}
+}
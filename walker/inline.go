@@ -0,0 +1,55 @@
+package walker
+
+import "strings"
+
+// Select Graphic Rendition parameters enabling and disabling an ANSI
+// style, the other styles of the nested texts are kept
+type ansiRendition struct {
+	on  string
+	off string
+}
+
+var (
+	ansiReverse = ansiRendition{on: "7", off: "27"}
+	ansiItalic  = ansiRendition{on: "3", off: "23"}
+	ansiBold    = ansiRendition{on: "1", off: "22"}
+)
+
+// Style an inline text, the delimiters are repeated per level like the
+// Markdown strong text
+func styleInline(s string, style InlineStyle, level int, rendition ansiRendition) string {
+	if strings.TrimSpace(s) == "" {
+		return s
+	}
+
+	switch style {
+	case InlineStyleBackticks:
+		return "`" + s + "`"
+	case InlineStyleAsterisks:
+		delimiter := strings.Repeat("*", level)
+		return delimiter + s + delimiter
+	case InlineStyleUnderscores:
+		delimiter := strings.Repeat("_", level)
+		return delimiter + s + delimiter
+	case InlineStyleSlashes:
+		return "/" + s + "/"
+	case InlineStyleUppercase:
+		return strings.ToUpper(s)
+	case InlineStyleANSI:
+		return "\x1b[" + rendition.on + "m" + s + "\x1b[" + rendition.off + "m"
+	default:
+		return s
+	}
+}
+
+func (w *Walker) styleCodeSpan(s string) string {
+	return styleInline(s, w.options.CodeSpanStyle, 1, ansiReverse)
+}
+
+func (w *Walker) styleEmphasis(s string) string {
+	return styleInline(s, w.options.EmphasisStyle, 1, ansiItalic)
+}
+
+func (w *Walker) styleStrong(s string) string {
+	return styleInline(s, w.options.StrongStyle, 2, ansiBold)
+}
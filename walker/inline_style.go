@@ -0,0 +1,65 @@
+package walker
+
+import "fmt"
+
+type InlineStyle int
+
+const (
+	// The text as is
+	InlineStylePlain InlineStyle = iota
+	// Surrounded with backticks
+	InlineStyleBackticks
+	// Surrounded with one asterisk, or two for the strong text
+	InlineStyleAsterisks
+	// Surrounded with one underscore, or two for the strong text
+	InlineStyleUnderscores
+	// Surrounded with slashes
+	InlineStyleSlashes
+	InlineStyleUppercase
+	// ANSI escape sequences, reverse video for the code spans, italic for
+	// the emphasis and bold for the strong text
+	InlineStyleANSI
+)
+
+func NewInlineStyleFromString(s string) (InlineStyle, error) {
+	switch s {
+	case "plain":
+		return InlineStylePlain, nil
+	case "backticks":
+		return InlineStyleBackticks, nil
+	case "asterisks":
+		return InlineStyleAsterisks, nil
+	case "underscores":
+		return InlineStyleUnderscores, nil
+	case "slashes":
+		return InlineStyleSlashes, nil
+	case "uppercase":
+		return InlineStyleUppercase, nil
+	case "ansi":
+		return InlineStyleANSI, nil
+	default:
+		return InlineStylePlain, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+func (i InlineStyle) String() string {
+	switch i {
+	case InlineStylePlain:
+		return "plain"
+	case InlineStyleBackticks:
+		return "backticks"
+	case InlineStyleAsterisks:
+		return "asterisks"
+	case InlineStyleUnderscores:
+		return "underscores"
+	case InlineStyleSlashes:
+		return "slashes"
+	case InlineStyleUppercase:
+		return "uppercase"
+	case InlineStyleANSI:
+		return "ansi"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
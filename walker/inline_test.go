@@ -0,0 +1,80 @@
+package walker
+
+import (
+	"testing"
+)
+
+func TestWalkInlineStyles(t *testing.T) {
+	source := "Run `rm -rf /` *now*, **really** and _maybe_.\n"
+
+	tests := []struct {
+		codeSpanStyle InlineStyle
+		emphasisStyle InlineStyle
+		strongStyle   InlineStyle
+		expected      string
+	}{
+		{
+			InlineStylePlain, InlineStylePlain, InlineStylePlain,
+			"\nRun rm -rf / now, really and maybe.\n",
+		},
+		{
+			InlineStyleBackticks, InlineStyleAsterisks, InlineStyleAsterisks,
+			"\nRun `rm -rf /` *now*, **really** and *maybe*.\n",
+		},
+		{
+			InlineStyleBackticks, InlineStyleUnderscores, InlineStyleUnderscores,
+			"\nRun `rm -rf /` _now_, __really__ and _maybe_.\n",
+		},
+		{
+			InlineStylePlain, InlineStyleSlashes, InlineStyleUppercase,
+			"\nRun rm -rf / /now/, REALLY and /maybe/.\n",
+		},
+		{
+			InlineStyleANSI, InlineStyleANSI, InlineStyleANSI,
+			"\nRun \x1b[7mrm -rf /\x1b[27m \x1b[3mnow\x1b[23m, \x1b[1mreally\x1b[22m and \x1b[3mmaybe\x1b[23m.\n",
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.CodeSpanStyle = test.codeSpanStyle
+		options.EmphasisStyle = test.emphasisStyle
+		options.StrongStyle = test.strongStyle
+
+		testComparableHelper(t, comparable{
+			source:   source,
+			expected: test.expected,
+		}, options)
+	}
+}
+
+func TestWalkHTMLInlineStyles(t *testing.T) {
+	options := testTxtOptions(t)
+	options.CodeSpanStyle = InlineStyleBackticks
+	options.EmphasisStyle = InlineStyleUnderscores
+	options.StrongStyle = InlineStyleUppercase
+
+	// The code of the preformatted elements is not styled
+	testHTMLDocumentHelper(t, comparable{
+		source:   "<p>Use <code>ls</code> <em>or</em> <b>dir</b>.</p><pre><code>x</code></pre>",
+		expected: "Use `ls` _or_ DIR.\n\nx\n",
+	}, options)
+}
+
+func TestNewInlineStyleFromString(t *testing.T) {
+	for _, s := range []string{"plain", "backticks", "asterisks", "underscores", "slashes", "uppercase", "ansi"} {
+		style, err := NewInlineStyleFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if style.String() != s {
+			t.Fatalf("expected %s, got %s", s, style.String())
+		}
+	}
+
+	_, err := NewInlineStyleFromString("bold")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,34 @@
+package walker
+
+import "fmt"
+
+type InputFormat int
+
+const (
+	InputFormatMarkdown InputFormat = iota
+	// A whole HTML document
+	InputFormatHTML
+)
+
+func NewInputFormatFromString(s string) (InputFormat, error) {
+	switch s {
+	case "markdown":
+		return InputFormatMarkdown, nil
+	case "html":
+		return InputFormatHTML, nil
+	default:
+		return InputFormatMarkdown, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+func (i InputFormat) String() string {
+	switch i {
+	case InputFormatMarkdown:
+		return "markdown"
+	case InputFormatHTML:
+		return "html"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
@@ -1,40 +1,113 @@
 package walker
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
-	"github.com/muesli/reflow/wordwrap"
-	"github.com/theobori/lueur/gophermap"
+	"github.com/theobori/lueur/internal/wrap"
 	"github.com/yuin/goldmark"
+	meta "github.com/yuin/goldmark-meta"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/net/html"
 )
 
 type Walker struct {
-	node    ast.Node
-	source  []byte
-	options *Options
-	ctx     *Context
+	node ast.Node
+	// Root of a HTML document input, the Markdown node is not used then
+	htmlNode *html.Node
+	// The HTML source is a whole page, see NewHTMLDocumentWalkerWithOptions
+	htmlDocument bool
+	source       []byte
+	options      *Options
+	renderer     Renderer
+	ctx          *Context
+	// Language of the document front matter
+	language string
+	// Link reference definitions of the Markdown document
+	definitions []linkDefinition
+	// Document path without extension, the code block files are named
+	// after it
+	name string
 }
 
 func NewWalkerWithOptions(source []byte, options *Options) *Walker {
+	source = removeMarkers(source)
+
 	markdown := goldmark.New(
+		goldmark.WithParser(newMarkdownParser()),
 		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithExtensions(options.Extensions...),
+		goldmark.WithParserOptions(options.ParserOptions...),
+		goldmark.WithParserOptions(
+			parser.WithBlockParsers(
+				util.Prioritized(newFrontMatterParser(), frontMatterParserPriority),
+			),
+			parser.WithParagraphTransformers(
+				util.Prioritized(linkDefinitionsTransformer{}, linkDefinitionsTransformerPriority),
+			),
+			parser.WithASTTransformers(
+				util.Prioritized(htmlInlineLinkTransformer{}, htmlInlineLinkTransformerPriority),
+			),
+		),
 	)
 
 	p := markdown.Parser()
-	node := p.Parse(text.NewReader(source))
+	pc := parser.NewContext()
+	node := p.Parse(text.NewReader(source), parser.WithContext(pc))
+
+	// The front matter is optional
+	language, _ := meta.Get(pc)["lang"].(string)
+	definitions := linkDefinitions(pc)
+
+	return &Walker{
+		node:        node,
+		source:      source,
+		ctx:         NewDefaultContext(),
+		options:     options,
+		renderer:    options.Renderer(),
+		language:    language,
+		definitions: definitions,
+	}
+}
+
+// NewHTMLWalkerWithOptions creates a walker for an HTML source, its
+// elements are walked like the HTML embedded into Markdown
+func NewHTMLWalkerWithOptions(source []byte, options *Options) (*Walker, error) {
+	source = removeMarkers(source)
+
+	node, err := html.Parse(bytes.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
 
 	return &Walker{
-		node:    node,
-		source:  source,
-		ctx:     NewDefaultContext(),
-		options: options,
+		htmlNode: node,
+		source:   source,
+		ctx:      NewDefaultContext(),
+		options:  options,
+		renderer: options.Renderer(),
+	}, nil
+}
+
+// NewHTMLDocumentWalkerWithOptions creates a walker for a whole HTML page,
+// its title is the document heading and its main content is extracted
+func NewHTMLDocumentWalkerWithOptions(source []byte, options *Options) (*Walker, error) {
+	w, err := NewHTMLWalkerWithOptions(source, options)
+	if err != nil {
+		return nil, err
 	}
+
+	w.htmlDocument = true
+
+	return w, nil
 }
 
 func NewWalker(source []byte, domain string) *Walker {
@@ -44,7 +117,16 @@ func NewWalker(source []byte, domain string) *Walker {
 }
 
 func (w *Walker) walkEmphasis(node ast.Node) (string, error) {
-	return w.walkIteratorHelper(node)
+	s, err := w.walkIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	if node.(*ast.Emphasis).Level > 1 {
+		return w.styleStrong(s), nil
+	}
+
+	return w.styleEmphasis(s), nil
 }
 
 func (w *Walker) walkText(node ast.Node) (string, error) {
@@ -52,17 +134,45 @@ func (w *Walker) walkText(node ast.Node) (string, error) {
 
 	s := string(text.Value(w.source))
 
-	if text.SoftLineBreak() || text.HardLineBreak() {
+	h := w.hyphenator()
+	if h != nil && isHyphenatable(node) {
+		s = hyphenateText(s, h)
+	}
+
+	if text.HardLineBreak() {
 		s += "\n"
+	} else if text.SoftLineBreak() {
+		s += w.softLineBreak()
 	}
 
 	return s, nil
 }
 
+// The strings are added by the extensions, the code ones hold raw HTML
+// such as the comments of the goldmark-meta errors
+func (w *Walker) walkString(node ast.Node) (string, error) {
+	str := node.(*ast.String)
+
+	if str.IsCode() {
+		return "", nil
+	}
+
+	return string(str.Value), nil
+}
+
 func (w *Walker) walkParagraph(node ast.Node) (string, error) {
-	s, err := w.walkIteratorHelper(node)
-	if err != nil {
-		return "", err
+	var (
+		s   string
+		err error
+	)
+
+	if w.isTableOfContentsMarker(node) {
+		s = strings.TrimRight(w.tableOfContentsString(), "\n")
+	} else {
+		s, err = w.walkIteratorHelper(node)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	if node.HasBlankPreviousLines() {
@@ -74,19 +184,56 @@ func (w *Walker) walkParagraph(node ast.Node) (string, error) {
 	return s, nil
 }
 
-func (w *Walker) walkThematicBreak(_ ast.Node) (string, error) {
-	return "", nil
+func (w *Walker) walkThematicBreak(node ast.Node) (string, error) {
+	s := w.thematicBreak()
+
+	if node.HasBlankPreviousLines() {
+		s = "\n" + s
+	}
+
+	s += "\n"
+
+	return s, nil
 }
 
 func (w *Walker) walkHeading(node ast.Node) (string, error) {
+	heading := node.(*ast.Heading)
+
 	s, err := w.walkIteratorHelper(node)
 	if err != nil {
 		return "", err
 	}
 
+	number := w.nextHeadingNumber()
+	if w.options.NumberedHeadings && number != "" {
+		s = number + " " + s
+	}
+
+	s = w.headingText(s, heading.Level)
+
+	if node.HasBlankPreviousLines() {
+		s = "\n" + s
+	}
+
+	s += "\n"
+
+	return s, nil
+}
+
+// Level of a Markdown heading, 0 for the other nodes
+func markdownHeadingLevel(node ast.Node) int {
+	heading, isHeading := node.(*ast.Heading)
+	if !isHeading {
+		return 0
+	}
+
+	return heading.Level
+}
+
+// Shared by the Markdown and the HTML headings
+func (w *Walker) headingText(s string, level int) string {
 	if w.options.WriteFancyHeader {
-		heading := node.(*ast.Heading)
-		fancyPrefix := strings.Repeat("#", heading.Level)
+		fancyPrefix := strings.Repeat("#", level)
 
 		sLines := strings.Split(s, "\n")
 		for i, sLine := range sLines {
@@ -96,13 +243,9 @@ func (w *Walker) walkHeading(node ast.Node) (string, error) {
 		s = strings.Join(sLines, "\n")
 	}
 
-	if node.HasBlankPreviousLines() {
-		s = "\n" + s
-	}
+	s = w.styleHeading(s, level)
 
-	s += "\n"
-
-	return s, nil
+	return w.headingBlankLines(w.renderer.Heading(s, level))
 }
 
 func (w *Walker) walkAutoLink(node ast.Node) (string, error) {
@@ -115,12 +258,15 @@ func (w *Walker) walkAutoLink(node ast.Node) (string, error) {
 }
 
 func (w *Walker) walkBlockQuote(node ast.Node) (string, error) {
+	w.ctx.BlockQuoteDepth.Add()
+	defer w.ctx.BlockQuoteDepth.Remove()
+
 	s, err := w.walkIteratorHelper(node)
 	if err != nil {
 		return "", err
 	}
 
-	s = "“" + strings.Trim(s, "\n") + "”"
+	s = w.quoteText(s)
 
 	if node.HasBlankPreviousLines() {
 		s = "\n" + s
@@ -140,22 +286,30 @@ func (w *Walker) walkList(node ast.Node) (string, error) {
 	i := list.Start
 
 	for c := list.FirstChild(); c != nil; c = c.NextSibling() {
-		w.ctx.Indentation.Indent()
-		line, err := w.Walk(c)
-		if err != nil {
-			return "", err
-		}
-
-		line = strings.Trim(line, "\n")
-		line = marker + " " + line
+		prefix := marker + " "
 
 		if list.IsOrdered() {
-			line = strconv.Itoa(i) + line
+			prefix = strconv.Itoa(i) + prefix
 			i += 1
 		}
+
+		prefix = w.ctx.Indentation.IndentValue() + prefix
+
+		w.ctx.Indentation.Indent()
+		line, err := w.walkWithListItemPrefix(prefix, func() (string, error) {
+			return w.Walk(c)
+		})
+		if err != nil {
+			return "", err
+		}
 		w.ctx.Indentation.UnIndent()
 
-		line = w.ctx.Indentation.IndentValue() + strings.TrimLeft(line, " ")
+		// The wrapped lines and the following paragraphs of the item
+		// are aligned with its text
+		indent := strings.Repeat(" ", wrap.Width(prefix))
+		line = strings.Trim(line, "\n")
+		line = strings.ReplaceAll(line, continuationMarker, indent+hanging(indent))
+		line = prefix + hanging(indent) + line
 
 		items = append(items, line)
 	}
@@ -173,7 +327,7 @@ func (w *Walker) walkList(node ast.Node) (string, error) {
 
 func (w *Walker) walkLink(node ast.Node) (string, error) {
 	link := node.(*ast.Link)
-	destination := string(link.Destination)
+	destination := w.pageDestination(string(link.Destination))
 	title := string(link.Title)
 
 	return w.walkReferenceHelper(node, title, destination)
@@ -191,6 +345,34 @@ func (w *Walker) walkCodeBlock(node ast.Node) (string, error) {
 	s := string(node.Lines().Value(w.source))
 	s = strings.Trim(s, "\n")
 
+	language := ""
+	filename := ""
+	fencedCodeBlock, isFenced := node.(*ast.FencedCodeBlock)
+	if isFenced {
+		language = string(fencedCodeBlock.Language(w.source))
+
+		if fencedCodeBlock.Info != nil {
+			info := string(fencedCodeBlock.Info.Segment.Value(w.source))
+			filename = infoAttribute(info, "filename")
+		}
+	}
+
+	w.ctx.CodeBlockIndex += 1
+
+	if w.isCodeBlockFile(s) {
+		var err error
+
+		s, err = w.codeBlockFile(s, language, filename)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		s = w.presentCodeBlock(s, language)
+		s = w.renderer.CodeBlock(s, language)
+	}
+
+	s = alignLines(s, leftAlignmentMarker)
+
 	if node.HasBlankPreviousLines() {
 		s = "\n" + s
 	}
@@ -200,6 +382,15 @@ func (w *Walker) walkCodeBlock(node ast.Node) (string, error) {
 	return s, nil
 }
 
+// Walk a list item, the width of its prefix is kept in the context
+func (w *Walker) walkWithListItemPrefix(prefix string, walk func() (string, error)) (string, error) {
+	listItemWidth := w.ctx.ListItemWidth
+	w.ctx.ListItemWidth = wrap.Width(prefix)
+	defer func() { w.ctx.ListItemWidth = listItemWidth }()
+
+	return walk()
+}
+
 func (w *Walker) walkListItem(node ast.Node) (string, error) {
 	items := []string{}
 
@@ -210,6 +401,19 @@ func (w *Walker) walkListItem(node ast.Node) (string, error) {
 		}
 
 		line = strings.Trim(line, "\n")
+
+		// The nested lists have their own indentation
+		if _, isList := c.(*ast.List); !isList {
+			lines := strings.Split(line, "\n")
+			for i := range lines {
+				if len(items) > 0 || i > 0 {
+					lines[i] = continuationMarker + lines[i]
+				}
+			}
+
+			line = strings.Join(lines, "\n")
+		}
+
 		items = append(items, line)
 	}
 
@@ -225,9 +429,16 @@ func (w *Walker) walkTextBlock(node ast.Node) (string, error) {
 }
 
 func (w *Walker) walkHTMLBlock(node ast.Node) (string, error) {
-	b := node.Lines().Value(w.source)
+	htmlBlock := node.(*ast.HTMLBlock)
+
+	b := htmlBlock.Lines().Value(w.source)
 	s := string(b)
 
+	// The line closing the block, such as </pre>, is not one of its lines
+	if htmlBlock.HasClosure() {
+		s += string(htmlBlock.ClosureLine.Value(w.source))
+	}
+
 	return w.walkHTMLFromString(s)
 }
 
@@ -246,15 +457,31 @@ func (w *Walker) walkTable(_ ast.Node) (string, error) {
 }
 
 func (w *Walker) walkCodeSpan(node ast.Node) (string, error) {
-	return w.walkIteratorHelper(node)
+	s, err := w.walkIteratorHelper(node)
+	if err != nil {
+		return "", err
+	}
+
+	return w.styleCodeSpan(s), nil
 }
 
 func (w *Walker) walk(node ast.Node) (string, error) {
+	handler, hasHandler := w.options.NodeHandler(node.Kind())
+	if hasHandler {
+		return handler(w, node, w.walkNode)
+	}
+
+	return w.walkNode(node)
+}
+
+func (w *Walker) walkNode(node ast.Node) (string, error) {
 	switch node.(type) {
 	case *ast.Emphasis:
 		return w.walkEmphasis(node)
 	case *ast.Text:
 		return w.walkText(node)
+	case *ast.String:
+		return w.walkString(node)
 	case *ast.Paragraph:
 		return w.walkParagraph(node)
 	case *ast.Heading:
@@ -293,36 +520,28 @@ func (w *Walker) walk(node ast.Node) (string, error) {
 }
 
 func (w *Walker) formatDepthOneText(s string) (string, error) {
-	s = strings.TrimRight(s, "\n")
+	text := strings.TrimRight(s, "\n")
 
-	if s == "" {
-		return "", nil
-	}
-
-	s = wordwrap.String(s, w.options.WordWrapLimit())
+	builder := strings.Builder{}
 
-	sDest := ""
-	linesRaw := strings.SplitSeq(s, "\n")
-
-	for lineRaw := range linesRaw {
-		// prevention substitutions
-		//
-		// replace tabs with four spaces
-		lineRaw = strings.ReplaceAll(lineRaw, "\t", "    ")
-		// remove antislash at the end
-		lineRaw = strings.TrimRight(lineRaw, "\\")
+	// A block made of code block file links only keeps its blank line
+	if text == "" && len(w.ctx.CodeFileLines) > 0 && strings.HasPrefix(s, "\n\n") {
+		builder.WriteString(w.renderer.TextLine("") + "\n")
+	}
 
-		line := gophermap.Line{
-			ItemType:    gophermap.ItemTypeInlineText,
-			Description: lineRaw,
-			Path:        "/",
-			Domain:      w.options.Domain(),
-			Port:        w.options.Port()}
+	if text != "" {
+		for _, line := range w.wrapText(text) {
+			builder.WriteString(w.renderer.TextLine(line) + "\n")
+		}
+	}
 
-		sDest += line.StringFromFileFormat(w.options.FileFormat()) + "\n"
+	// The links of the code block files follow the block
+	for _, line := range w.ctx.CodeFileLines {
+		builder.WriteString(line + "\n")
 	}
+	w.ctx.CodeFileLines = nil
 
-	return sDest, nil
+	return builder.String(), nil
 }
 
 func (w *Walker) Walk(node ast.Node) (string, error) {
@@ -345,19 +564,135 @@ func (w *Walker) Walk(node ast.Node) (string, error) {
 	// output the references by using the dedicated Lines
 	//
 	// depth 0 -> document/root node
-	if len(w.ctx.ReferencesQueue) > 0 &&
-		(w.options.ReferencePosition() == AfterBlocks && w.ctx.Depth.Value() == 1) ||
-		(w.options.ReferencePosition() == AfterTraverse && w.ctx.Depth.Value() == 0) {
-		for _, line := range w.ctx.ReferencesQueue {
-			s += line.StringFromFileFormat(w.options.FileFormat()) + "\n"
+	if w.isReferencesOutputable() {
+		s += w.referencesString()
+	}
+
+	return s, nil
+}
+
+func (w *Walker) isReferencesOutputable() bool {
+	depth := w.ctx.Depth.Value()
+
+	hasReferences := len(w.ctx.ReferencesQueue) > 0 || len(w.ctx.CitedDefinitions) > 0
+
+	return hasReferences &&
+		((w.options.ReferencePosition() == AfterBlocks && depth == 1) ||
+			(w.options.ReferencePosition() == AfterTraverse && depth == 0) ||
+			(w.options.ReferencePosition() == Bibliography && depth == 0) ||
+			(w.options.ReferencePosition() == AfterSection && depth == 0))
+}
+
+// Format the queued references and empty the queue
+func (w *Walker) referencesString() string {
+	builder := strings.Builder{}
+
+	if w.options.ReferencePosition() == Bibliography {
+		builder.WriteString(w.bibliographyString())
+	}
+
+	for _, line := range w.ctx.ReferencesQueue {
+		builder.WriteString(w.renderer.ReferenceLine(&line) + "\n")
+	}
+
+	if !w.options.RestartReferenceNumbering {
+		w.ctx.ReferenceOffset += len(w.ctx.ReferencesQueue)
+	}
+
+	w.ctx.ClearQueues()
+
+	return builder.String()
+}
+
+// WalkTo walks the tree from the root and writes every depth 1 block
+// as soon as it has been formatted, so the whole output is never held
+// in memory
+func (w *Walker) WalkTo(writer io.Writer) error {
+	_, err := io.WriteString(writer, w.renderer.DocumentStart())
+	if err != nil {
+		return err
+	}
+
+	// The root node is at depth 1, its children are the blocks
+	w.ctx.Depth.Add()
+
+	if w.htmlNode != nil {
+		err = w.walkHTMLDocumentTo(writer)
+	} else {
+		err = w.walkBlocksTo(writer)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.ctx.Depth.Remove()
+
+	if w.isReferencesOutputable() {
+		_, err = io.WriteString(writer, w.referencesString())
+		if err != nil {
+			return err
 		}
+	}
+
+	_, err = io.WriteString(writer, w.renderer.DocumentEnd())
 
-		w.ctx.ReferencesQueue = nil
+	return err
+}
+
+func (w *Walker) walkBlocksTo(writer io.Writer) error {
+	if w.options.TableOfContents || w.options.NumberedHeadings {
+		w.ctx.Headings = w.collectHeadings()
 	}
 
-	return s, nil
+	// The table of contents is written at the top without marker
+	if w.options.TableOfContents && !w.hasTableOfContentsMarker() {
+		err := w.writeTableOfContents(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A document handler renders the whole tree at once
+	_, hasHandler := w.options.NodeHandler(ast.KindDocument)
+	if hasHandler {
+		s, err := w.walk(w.node)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(writer, s)
+
+		return err
+	}
+
+	for c := w.node.FirstChild(); c != nil; c = c.NextSibling() {
+		err := w.writeSectionReferences(writer, markdownHeadingLevel(c))
+		if err != nil {
+			return err
+		}
+
+		s, err := w.Walk(c)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(writer, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (w *Walker) WalkFromRoot() (string, error) {
-	return w.Walk(w.node)
+	builder := strings.Builder{}
+
+	err := w.WalkTo(&builder)
+	if err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
 }
@@ -0,0 +1,100 @@
+package walker
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// Roughly the size of a 100k lines Markdown document
+var benchmarkSource = []byte(strings.Repeat(`# Heading
+
+Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed [laoreet](https://a.com) eros nec
+interdum vestibulum. Sed elementum scelerisque euismod. Praesent pellentesque justo eu ex iaculis.
+
+- a
+- b
+  - c
+
+`+"```"+`
+codeblock
+`+"```"+`
+
+`, 10000))
+
+// Writer keeping the size of the largest write, the output held in memory
+// at once by the walker
+type peakWriter struct {
+	peak int
+}
+
+func (p *peakWriter) Write(b []byte) (int, error) {
+	p.peak = max(p.peak, len(b))
+
+	return len(b), nil
+}
+
+func (p *peakWriter) WriteString(s string) (int, error) {
+	p.peak = max(p.peak, len(s))
+
+	return len(s), nil
+}
+
+// The source is parsed once, only the walk is measured. Both walks
+// allocate as often, WalkTo allocates about 20% fewer bytes and holds a
+// single formatted block of the output instead of the whole output:
+//
+// BenchmarkWalkWholeTree  4420000 peak-B  138269822 B/op  3590053 allocs/op
+// BenchmarkWalkTo             279 peak-B  111761070 B/op  3590015 allocs/op
+func benchmarkWalker(b *testing.B) func() *Walker {
+	parsed := NewWalkerWithOptions(benchmarkSource, testOptions)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	return func() *Walker {
+		w := *parsed
+		w.ctx = NewDefaultContext()
+
+		return &w
+	}
+}
+
+// The whole tree is rendered into a single string before being written
+func BenchmarkWalkWholeTree(b *testing.B) {
+	newWalker := benchmarkWalker(b)
+	writer := peakWriter{}
+
+	for b.Loop() {
+		w := newWalker()
+
+		s, err := w.Walk(w.node)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		_, err = io.WriteString(&writer, s)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportMetric(float64(writer.peak), "peak-B")
+}
+
+// Every block is written as soon as it has been formatted
+func BenchmarkWalkTo(b *testing.B) {
+	newWalker := benchmarkWalker(b)
+	writer := peakWriter{}
+
+	for b.Loop() {
+		w := newWalker()
+
+		err := w.WalkTo(&writer)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportMetric(float64(writer.peak), "peak-B")
+}
@@ -3,7 +3,6 @@ package walker
 import (
 	"strings"
 
-	"github.com/theobori/lueur/gophermap"
 	"github.com/yuin/goldmark/ast"
 )
 
@@ -71,6 +70,13 @@ func (w *Walker) referenceDescription(node ast.Node, title string, destination s
 }
 
 func (w *Walker) walkReferenceHelper(node ast.Node, title string, destination string) (string, error) {
+	// The titles of the defined references are written in the bibliography
+	label := linkReferenceLabel(node)
+	_, isDefined := w.linkDefinition(label)
+	if isDefined {
+		title = ""
+	}
+
 	description, err := w.referenceDescription(node, title, destination)
 	if err != nil {
 		return "", err
@@ -81,16 +87,14 @@ func (w *Walker) walkReferenceHelper(node ast.Node, title string, destination st
 		return "", err
 	}
 
-	inlineAnswer := w.processReferenceLineEdgeCases(line, destination)
+	inlineAnswer := w.processReferenceLineEdgeCases(line, destination, label)
 
 	_, isAutoLink := node.(*ast.AutoLink)
-	if isAutoLink &&
-		w.options.FileFormat() == gophermap.FileFormatTxt &&
-		w.options.ReferencePosition() == AfterTraverse {
+	if isAutoLink && !w.renderer.QueueAutoLinks() {
 		return description, nil
 	}
 
-	w.ctx.ReferencesQueue = append(w.ctx.ReferencesQueue, *line)
+	w.queueReference(line, destination, label)
 
 	return inlineAnswer, nil
 }
@@ -1,6 +1,7 @@
 package walker
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/theobori/lueur/gophermap"
@@ -192,8 +193,8 @@ func TestWalkBlockQuote(t *testing.T) {
 > second line
 > third line`,
 			expected: testEmptyGophermapLineString + `i“first line	/	localhost	70
-isecond line	/	localhost	70
-ithird line”	/	localhost	70
+i second line	/	localhost	70
+i third line”	/	localhost	70
 `,
 		},
 	}
@@ -384,17 +385,17 @@ iphlog	/	localhost	70
 1phlog	/phlog	localhost	70
 i	/	localhost	70
 i“block quote tes d d a dd	/	localhost	70
-ibb	/	localhost	70
-ib	/	localhost	70
-ib	/	localhost	70
+i bb	/	localhost	70
+i b	/	localhost	70
+i b	/	localhost	70
 i	/	localhost	70
-iC c c c AA	/	localhost	70
-ia a	/	localhost	70
-id	/	localhost	70
+i C c c c AA	/	localhost	70
+i a a	/	localhost	70
+i d	/	localhost	70
 i	/	localhost	70
-i“c	/	localhost	70
-ic”	/	localhost	70
-iaa”	/	localhost	70
+i ‘c	/	localhost	70
+i  c’	/	localhost	70
+i aa”	/	localhost	70
 i	/	localhost	70
 iaaa	/	localhost	70
 ia	/	localhost	70
@@ -529,3 +530,119 @@ hWW	URL:https://google.fr	google.fr	443
 
 	testComparableMultipleHelper(t, tests, testOptions)
 }
+
+func TestWalkHangingIndentation(t *testing.T) {
+	tests := []comparable{
+		{
+			source: `- Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+  - Sed laoreet eros nec interdum vestibulum, sed elementum.
+- a
+`,
+			expected: `
+- Lorem ipsum dolor sit amet, consectetur
+  adipiscing elit.
+  - Sed laoreet eros nec interdum vestibulum,
+    sed elementum.
+- a
+`,
+		},
+		{
+			source: `10. Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+
+    Sed laoreet eros nec interdum vestibulum.
+`,
+			expected: `
+10. Lorem ipsum dolor sit amet, consectetur
+    adipiscing elit.
+    Sed laoreet eros nec interdum vestibulum.
+`,
+		},
+		{
+			source: `> Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+> Sed laoreet eros nec interdum vestibulum.
+`,
+			expected: `
+“Lorem ipsum dolor sit amet, consectetur
+ adipiscing elit.
+ Sed laoreet eros nec interdum vestibulum.”
+`,
+		},
+		{
+			source: `> - Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+`,
+			expected: `
+“- Lorem ipsum dolor sit amet, consectetur
+   adipiscing elit.”
+`,
+		},
+	}
+
+	testComparableMultipleHelper(t, tests, testTxtOptions(t))
+}
+
+func TestWalkEscapedLines(t *testing.T) {
+	source := `[a|b](https://a.com "tab	title")
+
+[[c|d]](/c.txt)
+`
+
+	testGopherMap := comparable{
+		source: source,
+		expected: testEmptyGophermapLineString + `itab    title	/	localhost	70
+htab    title	URL:https://a.com	a.com	443
+i	/	localhost	70
+i[c|d]	/	localhost	70
+0[c|d]	/c.txt	localhost	70
+`,
+	}
+
+	testComparableHelper(t, testGopherMap, testOptions)
+
+	testGPH := comparable{
+		source: source,
+		expected: `[i||/|localhost|70]
+[i|tab    title|/|localhost|70]
+[h|tab    title|URL:https://a.com|a.com|443]
+[i||/|localhost|70]
+[i|\[c\|d]|/|localhost|70]
+[0|\[c\|d]|/c.txt|localhost|70]
+`,
+	}
+
+	localOptions := *testOptions
+	localOptions.SetReferencePositionAndFileFormat(AfterBlocks, gophermap.FileFormatGPH)
+	testComparableHelper(t, testGPH, &localOptions)
+}
+
+func TestWalkTo(t *testing.T) {
+	source := []byte(`# a
+
+[a](https://a.com) b
+
+c https://a.com
+`)
+
+	for _, referencePosition := range []OutputPosition{AfterBlocks, AfterTraverse, Bibliography, AfterSection} {
+		localOptions := *testOptions
+		localOptions.SetReferencePositionAndFileFormat(referencePosition, gophermap.FileFormatGophermap)
+
+		// Walking the whole tree at once
+		w := NewWalkerWithOptions(source, &localOptions)
+		expected, err := w.Walk(w.node)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		builder := strings.Builder{}
+		err = NewWalkerWithOptions(source, &localOptions).WalkTo(&builder)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if builder.String() != expected {
+			diff := testDmp.DiffMain(builder.String(), expected, false)
+
+			t.Fatal(testDmp.DiffPrettyText(diff))
+		}
+	}
+}
@@ -2,17 +2,32 @@ package walker
 
 import (
 	"fmt"
+	"maps"
+	"strings"
 
 	"github.com/theobori/lueur/gophermap"
+	"github.com/theobori/lueur/internal/hyphen"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
 )
 
 const WordWrapLimitMinimum = 45
 
+const DefaultReferenceSectionLevel = 2
+
 type Options struct {
 	// Maximum amount of characters per line
 	wordWrapLimit int
 	// Control after which entity references will be outputed
 	referencePosition OutputPosition
+	// Queue the references of a destination once, reusing their number
+	DeduplicateReferences bool
+	// Heading level ending the sections of the after-section references,
+	// the higher level headings end them too
+	referenceSectionLevel int
+	// Number the after-section references from 1 in every section
+	RestartReferenceNumbering bool
 	// Gopher site domain
 	domain string
 	// Gopher port
@@ -23,6 +38,71 @@ type Options struct {
 	fileFormat gophermap.FileFormat
 	// Prefix for references path
 	PathPrefix string
+	// Creates the renderer used by the walkers
+	newRenderer NewRendererFunc
+	// The renderer has been set with SetRenderer, it is kept when the
+	// file format changes
+	hasCustomRenderer bool
+	// Goldmark extensions added to the Markdown parser
+	Extensions []goldmark.Extender
+	// Goldmark options given to the Markdown parser
+	ParserOptions []parser.Option
+	// Custom renderings per Markdown node kind
+	nodeHandlers map[ast.NodeKind]NodeHandler
+	// Custom renderings per HTML tag name
+	htmlHandlers map[string]HTMLHandler
+	// Drop the <nav>, <header>, <footer> and <aside> elements of the
+	// HTML documents
+	DropHTMLLayout bool
+	// Write a table of contents at the top of the Markdown documents or
+	// in place of the [TOC] marker
+	TableOfContents bool
+	// Deepest heading level of the table of contents, every level if 0
+	tableOfContentsMaxDepth int
+	// Prefix the headings with their section number
+	NumberedHeadings bool
+	// Split the Markdown documents into pages at the headings of this
+	// level, they are not split if 0
+	splitHeadingLevel int
+	// Styles applied in order to the headings per level
+	headingStyles map[int][]HeadingStyle
+	// Extra empty lines written before the headings
+	HeadingBlankLinesBefore int
+	// Extra empty lines written after the headings
+	HeadingBlankLinesAfter int
+	// Alignment of the wrapped text lines
+	TextAlignment TextAlignment
+	// Rendering of the block quotes
+	BlockQuoteStyle BlockQuoteStyle
+	// Language of the typographic quotation marks, the front matter "lang"
+	// of a document takes precedence
+	quoteLanguage string
+	// Rendering of the thematic breaks
+	ThematicBreakStyle ThematicBreakStyle
+	// Text of the ornament thematic breaks, "* * *" if empty
+	ThematicBreakOrnament string
+	// Write the language of the fenced code blocks as their caption
+	CodeBlockCaption bool
+	// Frame of the code blocks
+	CodeBlockFrame CodeBlockFrame
+	// Prefix the code lines with their number
+	CodeBlockLineNumbers bool
+	// Minimum amount of lines of the code blocks written into their own
+	// text file, they are never written into files if 0
+	codeBlockFileMinLines int
+	// Amount of lines kept above the link of a code block file
+	CodeBlockFilePreviewLines int
+	// Rendering of the inline code spans
+	CodeSpanStyle InlineStyle
+	// Rendering of the emphasized text
+	EmphasisStyle InlineStyle
+	// Rendering of the strong text
+	StrongStyle InlineStyle
+	// Hyphenate the words of the text when they are wrapped
+	Hyphenation bool
+	// Language of the hyphenation patterns, the front matter "lang" of a
+	// document takes precedence
+	hyphenationLanguage string
 }
 
 func NewOptions(
@@ -36,7 +116,9 @@ func NewOptions(
 ) (*Options, error) {
 	var err error
 
-	o := Options{}
+	o := Options{
+		referenceSectionLevel: DefaultReferenceSectionLevel,
+	}
 
 	err = o.SetWordWrapLimit(wordWrapLimit)
 	if err != nil {
@@ -97,6 +179,129 @@ func (o *Options) SetWordWrapLimit(wordWrapLimit int) error {
 	return nil
 }
 
+func (o *Options) TableOfContentsMaxDepth() int {
+	return o.tableOfContentsMaxDepth
+}
+
+func (o *Options) SetTableOfContentsMaxDepth(maxDepth int) error {
+	if maxDepth < 0 || maxDepth > HeadingLevelMaximum {
+		return fmt.Errorf(
+			"the table of contents maximum depth must be between 0 and %d",
+			HeadingLevelMaximum,
+		)
+	}
+
+	o.tableOfContentsMaxDepth = maxDepth
+
+	return nil
+}
+
+func (o *Options) SplitHeadingLevel() int {
+	return o.splitHeadingLevel
+}
+
+func (o *Options) SetSplitHeadingLevel(level int) error {
+	if level < 0 || level > HeadingLevelMaximum {
+		return fmt.Errorf(
+			"the split heading level must be between 0 and %d",
+			HeadingLevelMaximum,
+		)
+	}
+
+	o.splitHeadingLevel = level
+
+	return nil
+}
+
+// HeadingStyles returns the styles of a heading level, plain by default
+func (o *Options) HeadingStyles(level int) []HeadingStyle {
+	return o.headingStyles[level]
+}
+
+func (o *Options) SetHeadingStyles(level int, styles ...HeadingStyle) error {
+	if level < 1 || level > HeadingLevelMaximum {
+		return fmt.Errorf(
+			"the heading level must be between 1 and %d",
+			HeadingLevelMaximum,
+		)
+	}
+
+	headingStyles := maps.Clone(o.headingStyles)
+	if headingStyles == nil {
+		headingStyles = map[int][]HeadingStyle{}
+	}
+
+	headingStyles[level] = styles
+	o.headingStyles = headingStyles
+
+	return nil
+}
+
+func (o *Options) CodeBlockFileMinLines() int {
+	return o.codeBlockFileMinLines
+}
+
+func (o *Options) SetCodeBlockFileMinLines(minLines int) error {
+	if minLines < 0 {
+		return fmt.Errorf("the code block file minimum lines must be a positive integer")
+	}
+
+	o.codeBlockFileMinLines = minLines
+
+	return nil
+}
+
+func (o *Options) ReferenceSectionLevel() int {
+	return o.referenceSectionLevel
+}
+
+func (o *Options) SetReferenceSectionLevel(level int) error {
+	if level < 1 || level > HeadingLevelMaximum {
+		return fmt.Errorf(
+			"the reference section level must be between 1 and %d",
+			HeadingLevelMaximum,
+		)
+	}
+
+	o.referenceSectionLevel = level
+
+	return nil
+}
+
+func (o *Options) HyphenationLanguage() string {
+	return o.hyphenationLanguage
+}
+
+func (o *Options) SetHyphenationLanguage(language string) error {
+	if language != "" && !hyphen.IsSupported(language) {
+		return fmt.Errorf(
+			"the hyphenation language must be one of %s",
+			strings.Join(hyphen.Languages(), ", "),
+		)
+	}
+
+	o.hyphenationLanguage = language
+
+	return nil
+}
+
+func (o *Options) QuoteLanguage() string {
+	return o.quoteLanguage
+}
+
+func (o *Options) SetQuoteLanguage(language string) error {
+	if language != "" && !isQuoteLanguage(language) {
+		return fmt.Errorf(
+			"the quote language must be one of %s",
+			strings.Join(quoteLanguages(), ", "),
+		)
+	}
+
+	o.quoteLanguage = language
+
+	return nil
+}
+
 func (o *Options) ReferencePosition() OutputPosition {
 	return o.referencePosition
 }
@@ -105,16 +310,19 @@ func (o *Options) SetReferencePositionAndFileFormat(
 	referencePosition OutputPosition,
 	fileFormat gophermap.FileFormat,
 ) error {
-	if fileFormat == gophermap.FileFormatTxt && referencePosition != AfterTraverse {
-		return fmt.Errorf(
-			"reference position %s cannot be used with the file format %s",
-			referencePosition.String(),
-			fileFormat.String(),
-		)
+	newRenderer := o.newRenderer
+	if !o.hasCustomRenderer {
+		newRenderer = NewRendererFuncFromFileFormat(fileFormat)
+	}
+
+	err := newRenderer(o).ValidateReferencePosition(referencePosition)
+	if err != nil {
+		return err
 	}
 
 	o.referencePosition = referencePosition
 	o.fileFormat = fileFormat
+	o.newRenderer = newRenderer
 
 	return nil
 }
@@ -124,10 +332,14 @@ func (o *Options) Domain() string {
 }
 
 func (o *Options) SetDomain(domain string) error {
-	if o.fileFormat != gophermap.FileFormatTxt && domain == "" {
+	renderer := o.Renderer()
+
+	err := renderer.ValidateDomain(domain)
+	if err != nil {
 		return fmt.Errorf(
-			"the domain cannot be empty for the file format %s",
-			o.fileFormat.String(),
+			"%w for the file format %s",
+			err,
+			renderer.Extension(),
 		)
 	}
 
@@ -153,3 +365,74 @@ func (o *Options) SetPort(port int) error {
 func (o *Options) FileFormat() gophermap.FileFormat {
 	return o.fileFormat
 }
+
+// Renderer returns a new renderer for the options, the default one
+// is based on the file format
+func (o *Options) Renderer() Renderer {
+	if o.newRenderer == nil {
+		return NewRendererFuncFromFileFormat(o.fileFormat)(o)
+	}
+
+	return o.newRenderer(o)
+}
+
+// SetRenderer replaces the renderer created from the file format, it is
+// the way to output a format unknown by the walker
+func (o *Options) SetRenderer(newRenderer NewRendererFunc) error {
+	renderer := newRenderer(o)
+
+	err := renderer.ValidateReferencePosition(o.referencePosition)
+	if err != nil {
+		return err
+	}
+
+	err = renderer.ValidateDomain(o.domain)
+	if err != nil {
+		return fmt.Errorf(
+			"%w for the file format %s",
+			err,
+			renderer.Extension(),
+		)
+	}
+
+	o.newRenderer = newRenderer
+	o.hasCustomRenderer = true
+
+	return nil
+}
+
+// SetNodeHandler registers a custom rendering for a Markdown node kind,
+// it can be a kind added by a goldmark extension
+func (o *Options) SetNodeHandler(kind ast.NodeKind, handler NodeHandler) {
+	// The options are often copied, the handlers must not be shared
+	nodeHandlers := maps.Clone(o.nodeHandlers)
+	if nodeHandlers == nil {
+		nodeHandlers = map[ast.NodeKind]NodeHandler{}
+	}
+
+	nodeHandlers[kind] = handler
+	o.nodeHandlers = nodeHandlers
+}
+
+func (o *Options) NodeHandler(kind ast.NodeKind) (NodeHandler, bool) {
+	handler, hasHandler := o.nodeHandlers[kind]
+
+	return handler, hasHandler
+}
+
+// SetHTMLHandler registers a custom rendering for a HTML tag name
+func (o *Options) SetHTMLHandler(tag string, handler HTMLHandler) {
+	htmlHandlers := maps.Clone(o.htmlHandlers)
+	if htmlHandlers == nil {
+		htmlHandlers = map[string]HTMLHandler{}
+	}
+
+	htmlHandlers[tag] = handler
+	o.htmlHandlers = htmlHandlers
+}
+
+func (o *Options) HTMLHandler(tag string) (HTMLHandler, bool) {
+	handler, hasHandler := o.htmlHandlers[tag]
+
+	return handler, hasHandler
+}
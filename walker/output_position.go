@@ -9,6 +9,12 @@ const (
 	AfterBlocks OutputPosition = iota
 	// The node will be outputed after the AST has been evaluated
 	AfterTraverse
+	// The node will be outputed after the AST has been evaluated, in a
+	// section grouping the references by link definition label
+	Bibliography
+	// The node will be outputed before the next heading of the reference
+	// section level
+	AfterSection
 )
 
 func NewOutputPositionFromString(s string) (OutputPosition, error) {
@@ -17,6 +23,10 @@ func NewOutputPositionFromString(s string) (OutputPosition, error) {
 		return AfterBlocks, nil
 	case "after-all":
 		return AfterTraverse, nil
+	case "bibliography":
+		return Bibliography, nil
+	case "after-section":
+		return AfterSection, nil
 	default:
 		return AfterBlocks, fmt.Errorf("unsupported string value: %s", s)
 	}
@@ -28,6 +38,10 @@ func (o *OutputPosition) String() string {
 		return "after-block"
 	case AfterTraverse:
 		return "after-all"
+	case Bibliography:
+		return "bibliography"
+	case AfterSection:
+		return "after-section"
 	// Cannot reach this block
 	default:
 		return "unknown"
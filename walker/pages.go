@@ -0,0 +1,316 @@
+package walker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// Page is a file of a document split at its headings
+type Page struct {
+	// Path relative to the document directory, with the file extension
+	Path string
+	// Rendered page
+	Content string
+	// Plain text of a code block, written without the header and the footer
+	IsCodeFile bool
+}
+
+// The blocks of a page titled by its first heading
+type pageSection struct {
+	title string
+	nodes []ast.Node
+}
+
+// Anchor of a heading, its id attribute or a slug of its text
+func headingAnchor(heading *ast.Heading, source []byte) string {
+	id, hasID := heading.AttributeString("id")
+	if hasID {
+		value, isBytes := id.([]byte)
+		if isBytes {
+			return string(value)
+		}
+	}
+
+	return slug(nodeText(heading, source))
+}
+
+// Lower case letters, digits, hyphens and underscores like the
+// usual Markdown renderers
+func slug(s string) string {
+	builder := strings.Builder{}
+
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			builder.WriteRune(r)
+		case unicode.IsSpace(r):
+			builder.WriteRune('-')
+		}
+	}
+
+	return builder.String()
+}
+
+func (w *Walker) pagePath(name string, index int) string {
+	extension := w.renderer.Extension()
+
+	if index == 0 {
+		return name + "." + extension
+	}
+
+	return fmt.Sprintf("%s-%d.%s", name, index, extension)
+}
+
+// Group the blocks by page, a new page starts at every heading of the
+// split level, the blocks before the first one are the index
+func (w *Walker) pageSections() []pageSection {
+	sections := []pageSection{{}}
+	splitLevel := w.options.SplitHeadingLevel()
+
+	for c := w.node.FirstChild(); c != nil; c = c.NextSibling() {
+		heading, isHeading := c.(*ast.Heading)
+		if isHeading && heading.Level == splitLevel {
+			sections = append(sections, pageSection{
+				title: nodeText(heading, w.source),
+			})
+		}
+
+		last := &sections[len(sections)-1]
+		last.nodes = append(last.nodes, c)
+	}
+
+	// The index is titled by its first heading
+	for _, node := range sections[0].nodes {
+		heading, isHeading := node.(*ast.Heading)
+		if isHeading {
+			sections[0].title = nodeText(heading, w.source)
+			break
+		}
+	}
+
+	return sections
+}
+
+// Every heading anchor with the page it belongs to
+func (w *Walker) pageAnchors(sections []pageSection, name string) map[string]string {
+	anchors := map[string]string{}
+
+	for i, section := range sections {
+		for _, node := range section.nodes {
+			_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+				heading, isHeading := n.(*ast.Heading)
+				if !entering || !isHeading {
+					return ast.WalkContinue, nil
+				}
+
+				// The duplicated anchors are suffixed like the usual
+				// Markdown renderers do
+				anchor := headingAnchor(heading, w.source)
+				unique := anchor
+				for n := 1; anchors[unique] != ""; n++ {
+					unique = fmt.Sprintf("%s-%d", anchor, n)
+				}
+
+				anchors[unique] = w.pagePath(name, i)
+
+				return ast.WalkSkipChildren, nil
+			})
+		}
+	}
+
+	return anchors
+}
+
+// The intra-document links point at the page of their heading
+func (w *Walker) pageDestination(destination string) string {
+	if !strings.HasPrefix(destination, "#") {
+		return destination
+	}
+
+	path, hasAnchor := w.ctx.Anchors[strings.TrimPrefix(destination, "#")]
+	if !hasAnchor {
+		return destination
+	}
+
+	return path
+}
+
+// A menu line linking a page
+func (w *Walker) pageLine(description string, path string) (string, error) {
+	line, err := w.referenceLine(description, path)
+	if err != nil {
+		return "", err
+	}
+
+	return w.renderer.ReferenceLine(line) + "\n", nil
+}
+
+// The parent menu links every section, the sections link their neighbours
+func (w *Walker) pageLinks(sections []pageSection, name string, index int) ([]string, error) {
+	type link struct {
+		description string
+		index       int
+	}
+
+	links := []link{}
+
+	if index == 0 {
+		for i, section := range sections[1:] {
+			links = append(links, link{section.title, i + 1})
+		}
+	} else {
+		if index > 1 {
+			links = append(links, link{"Previous: " + sections[index-1].title, index - 1})
+		}
+
+		up := "Index"
+		if sections[0].title != "" {
+			up += ": " + sections[0].title
+		}
+		links = append(links, link{up, 0})
+
+		if index < len(sections)-1 {
+			links = append(links, link{"Next: " + sections[index+1].title, index + 1})
+		}
+	}
+
+	lines := []string{}
+	for _, l := range links {
+		line, err := w.pageLine(l.description, w.pagePath(name, l.index))
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+func (w *Walker) walkPageTo(
+	writer io.Writer,
+	nodes []ast.Node,
+	links []string,
+	isTableOfContentsPage bool,
+) error {
+	_, err := io.WriteString(writer, w.renderer.DocumentStart())
+	if err != nil {
+		return err
+	}
+
+	if isTableOfContentsPage {
+		err = w.writeTableOfContents(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.ctx.Depth.Add()
+
+	for _, node := range nodes {
+		err := w.writeSectionReferences(writer, markdownHeadingLevel(node))
+		if err != nil {
+			return err
+		}
+
+		s, err := w.Walk(node)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(writer, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.ctx.Depth.Remove()
+
+	if len(links) > 0 {
+		s := w.renderer.TextLine("") + "\n" + strings.Join(links, "")
+
+		_, err = io.WriteString(writer, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.isReferencesOutputable() {
+		_, err = io.WriteString(writer, w.referencesString())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(writer, w.renderer.DocumentEnd())
+
+	return err
+}
+
+// WalkPages walks a Markdown document split at the headings of the split
+// level. The name is the document path relative to the path prefix,
+// without its extension, the first page is the parent menu.
+func (w *Walker) WalkPages(name string) ([]Page, error) {
+	if w.node == nil {
+		return nil, fmt.Errorf("only the Markdown documents can be split")
+	}
+
+	if w.options.SplitHeadingLevel() == 0 {
+		return w.WalkDocument(name)
+	}
+
+	w.name = name
+
+	if w.options.TableOfContents || w.options.NumberedHeadings {
+		w.ctx.Headings = w.collectHeadings()
+	}
+
+	sections := w.pageSections()
+	w.ctx.Anchors = w.pageAnchors(sections, name)
+
+	pages := []Page{}
+
+	for i, section := range sections {
+		links, err := w.pageLinks(sections, name, i)
+		if err != nil {
+			return nil, err
+		}
+
+		builder := strings.Builder{}
+		isTableOfContentsPage := i == 0 &&
+			w.options.TableOfContents &&
+			!w.hasTableOfContentsMarker()
+
+		err = w.walkPageTo(&builder, section.nodes, links, isTableOfContentsPage)
+		if err != nil {
+			return nil, err
+		}
+
+		pages = append(pages, Page{
+			Path:    w.pagePath(name, i),
+			Content: builder.String(),
+		})
+	}
+
+	return append(pages, w.ctx.CodeFiles...), nil
+}
+
+// WalkDocument walks a document into a single page followed by its code
+// block files. The name is the document path relative to the path prefix,
+// without its extension.
+func (w *Walker) WalkDocument(name string) ([]Page, error) {
+	w.name = name
+
+	s, err := w.WalkFromRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	page := Page{Path: w.pagePath(name, 0), Content: s}
+
+	return append([]Page{page}, w.ctx.CodeFiles...), nil
+}
@@ -0,0 +1,145 @@
+package walker
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/yuin/goldmark/parser"
+)
+
+func TestWalkPages(t *testing.T) {
+	localOptions := *testOptions
+	localOptions.ParserOptions = []parser.Option{parser.WithHeadingAttribute()}
+
+	err := localOptions.SetSplitHeadingLevel(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := `# Manual
+
+See [usage](#use).
+
+## Install
+
+Run it.
+
+## Usage {#use}
+
+Back to [install](#install).
+`
+
+	w := NewWalkerWithOptions([]byte(source), &localOptions)
+
+	pages, err := w.WalkPages("docs/manual")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Page{
+		{
+			Path: "docs/manual.gophermap",
+			Content: testEmptyGophermapLineString + `iManual	/	localhost	70
+i	/	localhost	70
+iSee usage.	/	localhost	70
+1usage	/docs/manual-2.gophermap	localhost	70
+i	/	localhost	70
+1Install	/docs/manual-1.gophermap	localhost	70
+1Usage	/docs/manual-2.gophermap	localhost	70
+`,
+		},
+		{
+			Path: "docs/manual-1.gophermap",
+			Content: testEmptyGophermapLineString + `iInstall	/	localhost	70
+i	/	localhost	70
+iRun it.	/	localhost	70
+i	/	localhost	70
+1Index: Manual	/docs/manual.gophermap	localhost	70
+1Next: Usage	/docs/manual-2.gophermap	localhost	70
+`,
+		},
+		{
+			Path: "docs/manual-2.gophermap",
+			Content: testEmptyGophermapLineString + `iUsage	/	localhost	70
+i	/	localhost	70
+iBack to install.	/	localhost	70
+1install	/docs/manual-1.gophermap	localhost	70
+i	/	localhost	70
+1Previous: Install	/docs/manual-1.gophermap	localhost	70
+1Index: Manual	/docs/manual.gophermap	localhost	70
+`,
+		},
+	}
+
+	if len(pages) != len(expected) {
+		t.Fatalf("%d pages have been walked (expected: %d)", len(pages), len(expected))
+	}
+
+	for i, page := range pages {
+		if page.Path != expected[i].Path {
+			t.Fatalf("'%s' is not the right page path (expected: '%s')", page.Path, expected[i].Path)
+		}
+
+		if page.Content != expected[i].Content {
+			diff := testDmp.DiffMain(page.Content, expected[i].Content, false)
+
+			t.Fatal(testDmp.DiffPrettyText(diff))
+		}
+	}
+}
+
+func TestWalkPagesWithoutSplit(t *testing.T) {
+	w := NewWalkerWithOptions([]byte("## A\n\n## B\n"), testOptions)
+
+	pages, err := w.WalkPages("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pages) != 1 || pages[0].Path != "a.gophermap" {
+		t.Fatalf("the document should not be split: %v", pages)
+	}
+}
+
+func TestPageAnchors(t *testing.T) {
+	localOptions := *testOptions
+
+	err := localOptions.SetSplitHeadingLevel(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWalkerWithOptions([]byte("## A\n\n## Notes\n\n## B\n\n## Notes\n\n### Notes\n"), &localOptions)
+	anchors := w.pageAnchors(w.pageSections(), "a")
+
+	expected := map[string]string{
+		"a":       "a-1.gophermap",
+		"notes":   "a-2.gophermap",
+		"b":       "a-3.gophermap",
+		"notes-1": "a-4.gophermap",
+		"notes-2": "a-4.gophermap",
+	}
+
+	if !maps.Equal(anchors, expected) {
+		t.Fatalf("%v are not the right anchors (expected: %v)", anchors, expected)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"Hello World", "hello-world"},
+		{" Café, déjà vu! ", "café-déjà-vu"},
+		{"a_b-c", "a_b-c"},
+	}
+
+	for _, test := range tests {
+		s := slug(test.s)
+
+		if s != test.expected {
+			t.Fatalf("'%s' is not the right slug (expected: '%s')", s, test.expected)
+		}
+	}
+}
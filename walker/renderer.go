@@ -0,0 +1,94 @@
+package walker
+
+import (
+	"fmt"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+// Renderer formats the walker output, it is the only part of the walker
+// knowing about the output file format
+type Renderer interface {
+	// File extension of the outputs, without the leading dot
+	Extension() string
+	// Checks if the references can be outputed at the given position
+	ValidateReferencePosition(referencePosition OutputPosition) error
+	// Checks if the domain can be used by the renderer
+	ValidateDomain(domain string) error
+	// Written before the first block
+	DocumentStart() string
+	// Written after the last block and the remaining references
+	DocumentEnd() string
+	// A single line of text, without the line break
+	TextLine(text string) string
+	// A single reference line, without the line break
+	ReferenceLine(line *gophermap.Line) string
+	// Description of a numbered reference line
+	ReferenceDescription(description string, destination string) string
+	// Reports whether the autolinks are queued as references,
+	// their text being already their destination
+	QueueAutoLinks() bool
+	// Heading text before being splitted into text lines
+	Heading(text string, level int) string
+	// Code block text before being splitted into text lines
+	CodeBlock(code string, language string) string
+	// Text written for a thematic break
+	Separator() string
+}
+
+type NewRendererFunc func(options *Options) Renderer
+
+func NewRendererFuncFromFileFormat(fileFormat gophermap.FileFormat) NewRendererFunc {
+	switch fileFormat {
+	case gophermap.FileFormatGPH:
+		return NewGPHRenderer
+	case gophermap.FileFormatTxt:
+		return NewTxtRenderer
+	default:
+		return NewGophermapRenderer
+	}
+}
+
+// BaseRenderer implements the parts of a Renderer that most of the
+// file formats share, it is meant to be embedded
+type BaseRenderer struct{}
+
+func (b *BaseRenderer) ValidateReferencePosition(_ OutputPosition) error {
+	return nil
+}
+
+func (b *BaseRenderer) ValidateDomain(domain string) error {
+	if domain == "" {
+		return fmt.Errorf("the domain cannot be empty")
+	}
+
+	return nil
+}
+
+func (b *BaseRenderer) DocumentStart() string {
+	return ""
+}
+
+func (b *BaseRenderer) DocumentEnd() string {
+	return ""
+}
+
+func (b *BaseRenderer) ReferenceDescription(description string, _ string) string {
+	return description
+}
+
+func (b *BaseRenderer) QueueAutoLinks() bool {
+	return true
+}
+
+func (b *BaseRenderer) Heading(text string, _ int) string {
+	return text
+}
+
+func (b *BaseRenderer) CodeBlock(code string, _ string) string {
+	return code
+}
+
+func (b *BaseRenderer) Separator() string {
+	return ""
+}
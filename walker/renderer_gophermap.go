@@ -0,0 +1,32 @@
+package walker
+
+import "github.com/theobori/lueur/gophermap"
+
+type GophermapRenderer struct {
+	BaseRenderer
+	options *Options
+}
+
+func NewGophermapRenderer(options *Options) Renderer {
+	return &GophermapRenderer{options: options}
+}
+
+func (g *GophermapRenderer) Extension() string {
+	return gophermap.FileFormatGophermap.String()
+}
+
+func (g *GophermapRenderer) TextLine(text string) string {
+	line := gophermap.Line{
+		ItemType:    gophermap.ItemTypeInlineText,
+		Description: text,
+		Path:        "/",
+		Domain:      g.options.Domain(),
+		Port:        g.options.Port(),
+	}
+
+	return line.StringGophermapFormat()
+}
+
+func (g *GophermapRenderer) ReferenceLine(line *gophermap.Line) string {
+	return line.StringGophermapFormat()
+}
@@ -0,0 +1,32 @@
+package walker
+
+import "github.com/theobori/lueur/gophermap"
+
+type GPHRenderer struct {
+	BaseRenderer
+	options *Options
+}
+
+func NewGPHRenderer(options *Options) Renderer {
+	return &GPHRenderer{options: options}
+}
+
+func (g *GPHRenderer) Extension() string {
+	return gophermap.FileFormatGPH.String()
+}
+
+func (g *GPHRenderer) TextLine(text string) string {
+	line := gophermap.Line{
+		ItemType:    gophermap.ItemTypeInlineText,
+		Description: text,
+		Path:        "/",
+		Domain:      g.options.Domain(),
+		Port:        g.options.Port(),
+	}
+
+	return line.StringGPHFormat()
+}
+
+func (g *GPHRenderer) ReferenceLine(line *gophermap.Line) string {
+	return line.StringGPHFormat()
+}
@@ -0,0 +1,148 @@
+package walker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+// Renders the references as Markdown links
+type testMarkdownRenderer struct {
+	BaseRenderer
+}
+
+func newTestMarkdownRenderer(_ *Options) Renderer {
+	return &testMarkdownRenderer{}
+}
+
+func (t *testMarkdownRenderer) Extension() string {
+	return "md"
+}
+
+func (t *testMarkdownRenderer) DocumentStart() string {
+	return "<!-- start -->\n"
+}
+
+func (t *testMarkdownRenderer) DocumentEnd() string {
+	return "<!-- end -->\n"
+}
+
+func (t *testMarkdownRenderer) TextLine(text string) string {
+	return text
+}
+
+func (t *testMarkdownRenderer) ReferenceLine(line *gophermap.Line) string {
+	return "=> " + line.Path + " " + line.Description
+}
+
+func (t *testMarkdownRenderer) Heading(text string, level int) string {
+	return strings.Repeat("#", level) + " " + text
+}
+
+func (t *testMarkdownRenderer) CodeBlock(code string, language string) string {
+	return "```" + language + "\n" + code + "\n```"
+}
+
+func (t *testMarkdownRenderer) Separator() string {
+	return "---"
+}
+
+func TestRendererFromFileFormat(t *testing.T) {
+	for _, fileFormat := range []gophermap.FileFormat{
+		gophermap.FileFormatGophermap,
+		gophermap.FileFormatGPH,
+		gophermap.FileFormatTxt,
+	} {
+		renderer := NewRendererFuncFromFileFormat(fileFormat)(testOptions)
+
+		if renderer.Extension() != fileFormat.String() {
+			t.Fatalf(
+				"'%s' is not the right extension (expected: '%s')",
+				renderer.Extension(),
+				fileFormat.String(),
+			)
+		}
+	}
+}
+
+func TestTxtRendererReferencePositions(t *testing.T) {
+	renderer := NewRendererFuncFromFileFormat(gophermap.FileFormatTxt)(testOptions)
+
+	for _, referencePosition := range []OutputPosition{AfterTraverse, Bibliography, AfterSection} {
+		err := renderer.ValidateReferencePosition(referencePosition)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A text file has no link to write after the blocks
+	err := renderer.ValidateReferencePosition(AfterBlocks)
+	if err == nil {
+		t.Fatal("the reference position after-block should not be valid")
+	}
+}
+
+func TestCustomRenderer(t *testing.T) {
+	test := comparable{
+		source: `# Title
+
+text [link](/a.txt)
+
+***
+
+` + "```go" + `
+code
+` + "```",
+		expected: `<!-- start -->
+
+# Title
+
+text link
+=> /a.txt link
+
+---
+
+` + "```go" + `
+code
+` + "```" + `
+<!-- end -->
+`,
+	}
+
+	localOptions := *testOptions
+	err := localOptions.SetRenderer(newTestMarkdownRenderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testComparableHelper(t, test, &localOptions)
+}
+
+func TestCustomRendererKept(t *testing.T) {
+	localOptions := *testOptions
+	err := localOptions.SetRenderer(newTestMarkdownRenderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The custom renderer validates the reference position
+	err = localOptions.SetReferencePositionAndFileFormat(AfterTraverse, gophermap.FileFormatTxt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extension := localOptions.Renderer().Extension()
+	if extension != "md" {
+		t.Fatalf("the custom renderer has been replaced by the %s one", extension)
+	}
+}
+
+func TestSetRendererValidation(t *testing.T) {
+	localOptions := *testOptions
+
+	err := localOptions.SetRenderer(NewTxtRenderer)
+	if err == nil {
+		t.Fatal("the txt renderer should not accept the after-block reference position")
+	}
+}
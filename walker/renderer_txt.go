@@ -0,0 +1,56 @@
+package walker
+
+import (
+	"fmt"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+type TxtRenderer struct {
+	BaseRenderer
+}
+
+func NewTxtRenderer(_ *Options) Renderer {
+	return &TxtRenderer{}
+}
+
+func (t *TxtRenderer) Extension() string {
+	return gophermap.FileFormatTxt.String()
+}
+
+// The references can only be numbered or labeled, a text file has no link
+func (t *TxtRenderer) ValidateReferencePosition(referencePosition OutputPosition) error {
+	switch referencePosition {
+	case AfterTraverse, Bibliography, AfterSection:
+		return nil
+	default:
+		return fmt.Errorf(
+			"reference position %s cannot be used with the file format %s",
+			referencePosition.String(),
+			t.Extension(),
+		)
+	}
+}
+
+func (t *TxtRenderer) ValidateDomain(_ string) error {
+	return nil
+}
+
+func (t *TxtRenderer) TextLine(text string) string {
+	line := gophermap.Line{Description: text}
+
+	return line.StringTextFormat()
+}
+
+func (t *TxtRenderer) ReferenceLine(line *gophermap.Line) string {
+	return line.StringTextFormat()
+}
+
+// The destination is the only useful information in a text file
+func (t *TxtRenderer) ReferenceDescription(_ string, destination string) string {
+	return destination
+}
+
+func (t *TxtRenderer) QueueAutoLinks() bool {
+	return false
+}
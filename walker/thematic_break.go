@@ -0,0 +1,32 @@
+package walker
+
+import (
+	"strings"
+
+	"github.com/theobori/lueur/internal/wrap"
+)
+
+// Ornament of the thematic breaks when the options have none
+const DefaultThematicBreakOrnament = "* * *"
+
+// The separator line of a thematic break, it is never wrapped so it is
+// as wide as the text of its list item or of its block quote
+func (w *Walker) thematicBreak() string {
+	limit := max(w.options.WordWrapLimit()-w.ctx.ListItemWidth-w.quotePrefixWidth(), 1)
+
+	switch w.options.ThematicBreakStyle {
+	case ThematicBreakStyleDashes:
+		return noWrap([]string{strings.Repeat("-", limit)})
+	case ThematicBreakStyleBox:
+		return noWrap([]string{strings.Repeat("─", limit)})
+	case ThematicBreakStyleOrnament:
+		ornament := w.options.ThematicBreakOrnament
+		if ornament == "" {
+			ornament = DefaultThematicBreakOrnament
+		}
+
+		return noWrap([]string{wrap.Center(ornament, limit)})
+	default:
+		return w.renderer.Separator()
+	}
+}
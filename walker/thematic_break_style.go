@@ -0,0 +1,47 @@
+package walker
+
+import "fmt"
+
+type ThematicBreakStyle int
+
+const (
+	// The separator of the renderer, empty for the bundled file formats
+	ThematicBreakStyleNone ThematicBreakStyle = iota
+	// A line of dashes as wide as the word wrap limit
+	ThematicBreakStyleDashes
+	// A box-drawing line as wide as the word wrap limit
+	ThematicBreakStyleBox
+	// The ornament centered within the word wrap limit
+	ThematicBreakStyleOrnament
+)
+
+func NewThematicBreakStyleFromString(s string) (ThematicBreakStyle, error) {
+	switch s {
+	case "none":
+		return ThematicBreakStyleNone, nil
+	case "dashes":
+		return ThematicBreakStyleDashes, nil
+	case "box":
+		return ThematicBreakStyleBox, nil
+	case "ornament":
+		return ThematicBreakStyleOrnament, nil
+	default:
+		return ThematicBreakStyleNone, fmt.Errorf("unsupported string value: %s", s)
+	}
+}
+
+func (t ThematicBreakStyle) String() string {
+	switch t {
+	case ThematicBreakStyleNone:
+		return "none"
+	case ThematicBreakStyleDashes:
+		return "dashes"
+	case ThematicBreakStyleBox:
+		return "box"
+	case ThematicBreakStyleOrnament:
+		return "ornament"
+	// Cannot reach this block
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,66 @@
+package walker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWalkThematicBreakStyles(t *testing.T) {
+	source := "a\n\n---\n\nb\n\n<hr>\n"
+
+	tests := []struct {
+		style     ThematicBreakStyle
+		ornament  string
+		separator string
+	}{
+		{ThematicBreakStyleDashes, "", strings.Repeat("-", WordWrapLimitMinimum)},
+		{ThematicBreakStyleBox, "", strings.Repeat("─", WordWrapLimitMinimum)},
+		{ThematicBreakStyleOrnament, "", strings.Repeat(" ", 20) + "* * *"},
+		{ThematicBreakStyleOrnament, "~", strings.Repeat(" ", 22) + "~"},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.ThematicBreakStyle = test.style
+		options.ThematicBreakOrnament = test.ornament
+
+		testComparableHelper(t, comparable{
+			source:   source,
+			expected: "\na\n\n" + test.separator + "\n\nb\n\n" + test.separator + "\n",
+		}, options)
+	}
+
+	// The bundled renderers have no separator
+	testComparableHelper(t, comparable{
+		source:   source,
+		expected: "\na\n\nb\n",
+	}, testTxtOptions(t))
+}
+
+func TestWalkThematicBreakWidth(t *testing.T) {
+	source := "- a\n\n  ---\n\n> b\n>\n> ---\n"
+
+	tests := []struct {
+		blockQuoteStyle BlockQuoteStyle
+		expected        string
+	}{
+		{
+			blockQuoteStyle: BlockQuoteStyleEmail,
+			expected: "\n- a\n  " + strings.Repeat("-", WordWrapLimitMinimum-2) +
+				"\n\n> b\n>\n> " + strings.Repeat("-", WordWrapLimitMinimum-2) + "\n",
+		},
+		{
+			blockQuoteStyle: BlockQuoteStyleBar,
+			expected: "\n- a\n  " + strings.Repeat("-", WordWrapLimitMinimum-2) +
+				"\n\n│ b\n│\n│ " + strings.Repeat("-", WordWrapLimitMinimum-2) + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		options := testTxtOptions(t)
+		options.ThematicBreakStyle = ThematicBreakStyleDashes
+		options.BlockQuoteStyle = test.blockQuoteStyle
+
+		testComparableHelper(t, comparable{source: source, expected: test.expected}, options)
+	}
+}
@@ -0,0 +1,158 @@
+package walker
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// Paragraph replaced by the table of contents
+const TableOfContentsMarker = "[TOC]"
+
+// Maximum Markdown heading level
+const HeadingLevelMaximum = 6
+
+type tocEntry struct {
+	level int
+	// Section number, such as "1.2."
+	number string
+	title  string
+}
+
+// Concatenated text of every descendant
+func nodeText(node ast.Node, source []byte) string {
+	builder := strings.Builder{}
+
+	for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+		switch n := c.(type) {
+		case *ast.Text:
+			builder.Write(n.Value(source))
+		case *ast.String:
+			builder.Write(n.Value)
+		default:
+			builder.WriteString(nodeText(c, source))
+		}
+	}
+
+	return builder.String()
+}
+
+// Collect the headings in the document order and number them from the
+// lowest level found
+func (w *Walker) collectHeadings() []tocEntry {
+	entries := []tocEntry{}
+	minLevel := HeadingLevelMaximum
+
+	_ = ast.Walk(w.node, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, isHeading := node.(*ast.Heading)
+		if !entering || !isHeading {
+			return ast.WalkContinue, nil
+		}
+
+		entries = append(entries, tocEntry{
+			level: heading.Level,
+			title: nodeText(heading, w.source),
+		})
+		minLevel = min(minLevel, heading.Level)
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	counters := make([]int, HeadingLevelMaximum+1)
+
+	for i, entry := range entries {
+		counters[entry.level] += 1
+		for level := entry.level + 1; level <= HeadingLevelMaximum; level++ {
+			counters[level] = 0
+		}
+
+		number := ""
+		for level := minLevel; level <= entry.level; level++ {
+			number += strconv.Itoa(counters[level]) + "."
+		}
+
+		entries[i].level = entry.level - minLevel + 1
+		entries[i].number = number
+	}
+
+	return entries
+}
+
+// Only the markers written at the top level of the document are replaced,
+// the ones of the lists and of the quotes are kept as text
+func (w *Walker) isTableOfContentsMarker(node ast.Node) bool {
+	if !w.options.TableOfContents || node.Parent() == nil || node.Parent().Kind() != ast.KindDocument {
+		return false
+	}
+
+	value := node.Lines().Value(w.source)
+
+	return strings.TrimSpace(string(value)) == TableOfContentsMarker
+}
+
+// Whether the document has a marker for the table of contents
+func (w *Walker) hasTableOfContentsMarker() bool {
+	for c := w.node.FirstChild(); c != nil; c = c.NextSibling() {
+		if c.Kind() == ast.KindParagraph && w.isTableOfContentsMarker(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The entries are indented by level, up to the maximum depth
+func (w *Walker) tableOfContentsString() string {
+	maxDepth := w.options.TableOfContentsMaxDepth()
+	lines := []string{}
+
+	for _, entry := range w.ctx.Headings {
+		if maxDepth > 0 && entry.level > maxDepth {
+			continue
+		}
+
+		line := strings.Repeat(" ", 2*(entry.level-1))
+		if w.options.NumberedHeadings {
+			line += entry.number + " "
+		}
+
+		lines = append(lines, line+entry.title)
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// The number of the next walked heading
+func (w *Walker) nextHeadingNumber() string {
+	if w.ctx.HeadingIndex >= len(w.ctx.Headings) {
+		return ""
+	}
+
+	number := w.ctx.Headings[w.ctx.HeadingIndex].number
+	w.ctx.HeadingIndex += 1
+
+	return number
+}
+
+// Write the table of contents separated from the first block by an empty line
+func (w *Walker) writeTableOfContents(writer io.Writer) error {
+	s, err := w.formatDepthOneText(w.tableOfContentsString())
+	if err != nil || s == "" {
+		return err
+	}
+
+	first := w.node.FirstChild()
+	if first != nil && !first.HasBlankPreviousLines() {
+		s += w.renderer.TextLine("") + "\n"
+	}
+
+	_, err = io.WriteString(writer, s)
+
+	return err
+}
@@ -0,0 +1,126 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/theobori/lueur/gophermap"
+)
+
+func TestWalkTableOfContents(t *testing.T) {
+	localOptions, _ := NewOptions(
+		80,
+		AfterTraverse,
+		"",
+		70,
+		false,
+		gophermap.FileFormatTxt,
+		"",
+	)
+	localOptions.TableOfContents = true
+
+	tests := []comparable{
+		{
+			source: `Intro
+
+## A
+
+### A1 ` + "`code`" + `
+
+## B
+`,
+			expected: `A
+  A1 code
+B
+
+Intro
+
+A
+
+A1 code
+
+B
+`,
+		},
+		{
+			source: `# Title
+
+[TOC]
+
+## A
+`,
+			expected: `
+Title
+
+Title
+  A
+
+A
+`,
+		},
+		{
+			source:   "No heading\n",
+			expected: "\nNo heading\n",
+		},
+		// The nested markers are not replaced, the table of contents is
+		// written before the document
+		{
+			source: `- [TOC]
+
+> [TOC]
+
+## A
+`,
+			expected: `A
+
+- [TOC]
+
+“[TOC]”
+
+A
+`,
+		},
+	}
+
+	testComparableMultipleHelper(t, tests, localOptions)
+}
+
+func TestWalkTableOfContentsMaxDepth(t *testing.T) {
+	localOptions := *testOptions
+	localOptions.TableOfContents = true
+	localOptions.NumberedHeadings = true
+
+	err := localOptions.SetTableOfContentsMaxDepth(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := comparable{
+		source: `## A
+
+### A1
+
+#### A1a
+
+## B
+`,
+		expected: `i1. A	/	localhost	70
+i  1.1. A1	/	localhost	70
+i2. B	/	localhost	70
+i	/	localhost	70
+i1. A	/	localhost	70
+i	/	localhost	70
+i1.1. A1	/	localhost	70
+i	/	localhost	70
+i1.1.1. A1a	/	localhost	70
+i	/	localhost	70
+i2. B	/	localhost	70
+`,
+	}
+
+	testComparableHelper(t, test, &localOptions)
+
+	err = localOptions.SetTableOfContentsMaxDepth(HeadingLevelMaximum + 1)
+	if err == nil {
+		t.Fatal("the maximum depth should be invalid")
+	}
+}
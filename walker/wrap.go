@@ -0,0 +1,171 @@
+package walker
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/theobori/lueur/internal/wrap"
+)
+
+// The markers are noncharacters, Unicode reserves them for the internal
+// use of the programs. They are removed from the sources, so the text of a
+// document is never taken for a marker.
+const (
+	firstMarker = '\uFDD0'
+	lastMarker  = '\uFDEF'
+)
+
+// Character marking the lines that must not be wrapped, it is removed
+// before the lines are rendered
+const noWrapMarker = "\uFDD0"
+
+// Characters around the prefix of the wrapped lines following a marked
+// line, such as the indentation of a list item text
+const (
+	hangingStartMarker = "\uFDD1"
+	hangingEndMarker   = "\uFDD2"
+)
+
+// Character at the beginning of the lines continuing a list item, it is
+// replaced by the indentation of the item text
+const continuationMarker = "\uFDD3"
+
+// Characters forcing the alignment of a line, such as the lines of a code
+// block which are never justified
+const (
+	leftAlignmentMarker   = "\uFDD4"
+	centerAlignmentMarker = "\uFDD5"
+)
+
+func isMarker(r rune) bool {
+	return r >= firstMarker && r <= lastMarker
+}
+
+// Remove the markers from a source, the invalid UTF-8 sequences are kept
+func removeMarkers(source []byte) []byte {
+	if !bytes.ContainsFunc(source, isMarker) {
+		return source
+	}
+
+	s := make([]byte, 0, len(source))
+
+	for len(source) > 0 {
+		r, size := utf8.DecodeRune(source)
+		if !isMarker(r) {
+			s = append(s, source[:size]...)
+		}
+
+		source = source[size:]
+	}
+
+	return s
+}
+
+var lineMarkersReplacer = strings.NewReplacer(
+	continuationMarker, "",
+	leftAlignmentMarker, "",
+	centerAlignmentMarker, "",
+)
+
+// Mark the prefix of the wrapped lines following the current one
+func hanging(prefix string) string {
+	return hangingStartMarker + prefix + hangingEndMarker
+}
+
+// Mark the non empty lines with an alignment marker
+func alignLines(s string, marker string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = marker + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Split a line into its text without the hanging markers, the part of
+// the text before the last marker and the prefix of its wrapped lines.
+// The prefixes of the nested blocks are concatenated.
+func splitHanging(line string) (string, string, string) {
+	text := strings.Builder{}
+	prefix := strings.Builder{}
+
+	for {
+		start := strings.Index(line, hangingStartMarker)
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(line[start:], hangingEndMarker)
+		if end < 0 {
+			break
+		}
+		end += start
+
+		text.WriteString(line[:start])
+		prefix.WriteString(line[start+len(hangingStartMarker) : end])
+		line = line[end+len(hangingEndMarker):]
+	}
+
+	head := text.String()
+	text.WriteString(line)
+
+	return text.String(), head, prefix.String()
+}
+
+// The alignment of a line, the markers take precedence over the options
+func (w *Walker) lineAlignment(line string) TextAlignment {
+	switch {
+	case strings.Contains(line, leftAlignmentMarker):
+		return TextAlignmentLeft
+	case strings.Contains(line, centerAlignmentMarker):
+		return TextAlignmentCenter
+	default:
+		return w.options.TextAlignment
+	}
+}
+
+// Wrap the lines except the marked ones, they are kept as is
+func (w *Walker) wrapText(s string) []string {
+	lines := []string{}
+	limit := w.options.WordWrapLimit()
+
+	for line := range strings.SplitSeq(s, "\n") {
+		alignment := w.lineAlignment(line)
+		text, head, prefix := splitHanging(lineMarkersReplacer.Replace(line))
+
+		if strings.Contains(text, noWrapMarker) {
+			lines = append(lines, strings.ReplaceAll(text, noWrapMarker, ""))
+			continue
+		}
+
+		wrappedLines := wrap.Hanging(text, limit, prefix)
+
+		for i, wrappedLine := range wrappedLines {
+			// remove antislash at the end
+			wrappedLine = strings.TrimRight(wrappedLine, "\\")
+
+			switch alignment {
+			case TextAlignmentJustify:
+				// The last line of a paragraph is not justified
+				if i == len(wrappedLines)-1 {
+					break
+				}
+
+				if i == 0 {
+					wrappedLine = wrap.Justify(wrappedLine, limit, head)
+				} else {
+					wrappedLine = wrap.Justify(wrappedLine, limit, prefix)
+				}
+			case TextAlignmentCenter:
+				wrappedLine = wrap.Center(wrappedLine, limit)
+			}
+
+			lines = append(lines, wrappedLine)
+		}
+	}
+
+	return lines
+}
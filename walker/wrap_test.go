@@ -0,0 +1,35 @@
+package walker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveMarkers(t *testing.T) {
+	source := []byte("a\uFDD0b\uFDEF c \xff")
+	expected := "ab c \xff"
+
+	s := string(removeMarkers(source))
+	if s != expected {
+		t.Fatalf("%q is not the right source (expected: %q)", s, expected)
+	}
+}
+
+func TestWalkPrivateUseCharacters(t *testing.T) {
+	words := strings.Repeat(" word", 10)
+
+	// The glyphs of the icon fonts are wrapped like the other characters,
+	// the markers of the source are removed
+	testComparableHelper(t, comparable{
+		source: "\uE000" + words + "\n\n\uFDD0" + words + "\n",
+		expected: "\n\uE000" + strings.Repeat(" word", 8) + "\nword word\n" +
+			"\n" + words[1:45] + "\nword\n",
+	}, testTxtOptions(t))
+}
+
+func TestWalkListItemPrivateUseCharacters(t *testing.T) {
+	testComparableHelper(t, comparable{
+		source:   "- x \uE003y \uE001z\uE002\n",
+		expected: "\n- x \uE003y \uE001z\uE002\n",
+	}, testTxtOptions(t))
+}